@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/client"
+)
+
+// Exit codes the client reports, so scripts can tell failure classes
+// apart without parsing error messages.
+const (
+	exitOK       = 0
+	exitFailure  = 1 // anything not classified below
+	exitAuth     = 2 // wrong password, or an expired or revoked session
+	exitNotFound = 3 // the named item or resource does not exist
+	exitNetwork  = 4 // server unreachable or the call timed out
+	exitConflict = 5 // duplicate name or concurrent modification
+)
+
+// exitCode classifies err into one of the exit codes above. Wrapped
+// gRPC status codes are honoured, so errors passed through fmt.Errorf
+// with %w keep their class.
+func exitCode(err error) int {
+	var dup *client.DuplicateItemError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, client.ErrWrongMasterPassword):
+		return exitAuth
+	case errors.Is(err, client.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, client.ErrVersionConflict), errors.As(err, &dup):
+		return exitConflict
+	}
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return exitAuth
+	case codes.NotFound:
+		return exitNotFound
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return exitNetwork
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		return exitConflict
+	}
+	return exitFailure
+}
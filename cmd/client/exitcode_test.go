@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/client"
+)
+
+func TestExitCodeClassification(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, exitOK},
+		{"wrong master password", client.ErrWrongMasterPassword, exitAuth},
+		{"expired session", fmt.Errorf("list items: %w", status.Error(codes.Unauthenticated, "token expired")), exitAuth},
+		{"missing item", fmt.Errorf("no item named %q: %w", "x", client.ErrNotFound), exitNotFound},
+		{"server says not found", fmt.Errorf("get item: %w", status.Error(codes.NotFound, "no such item")), exitNotFound},
+		{"server down", fmt.Errorf("sync: %w", status.Error(codes.Unavailable, "connection refused")), exitNetwork},
+		{"timeout", status.Error(codes.DeadlineExceeded, "deadline exceeded"), exitNetwork},
+		{"edit conflict", client.ErrVersionConflict, exitConflict},
+		{"duplicate name", &client.DuplicateItemError{Name: "mail"}, exitConflict},
+		{"unclassified", fmt.Errorf("read file: permission denied"), exitFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Fatalf("exitCode = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
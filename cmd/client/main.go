@@ -0,0 +1,1405 @@
+// The client command is the GophKeeper CLI.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/ar11/gophkeeper/internal/client"
+	"github.com/ar11/gophkeeper/internal/crypto"
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+const defaultServerAddress = "localhost:3200"
+
+// infoOut receives informational chatter such as "logged in". Command
+// results always go straight to stdout; -quiet swaps infoOut for
+// io.Discard so scripts only see results and errors.
+var infoOut io.Writer = os.Stdout
+
+// diag logs diagnostics (server address, timings) to stderr; -verbose
+// lowers its level to debug.
+var diag = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+// applyLogFlags installs the output level chosen by -verbose/-quiet.
+func applyLogFlags(verbose, quiet bool) {
+	if quiet {
+		infoOut = io.Discard
+	}
+	level := slog.LevelWarn
+	if verbose {
+		level = slog.LevelDebug
+	}
+	diag = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+}
+
+// info prints an informational line, suppressed by -quiet.
+func info(format string, args ...any) {
+	fmt.Fprintf(infoOut, format, args...)
+}
+
+// connFlags are the server-connection flags shared by every command.
+type connFlags struct {
+	server     *string
+	caCert     *string
+	insecure   *bool
+	offline    *bool
+	maxMsgSize *int
+	retries    *int
+	retryDelay *time.Duration
+	timeout    *time.Duration
+	verbose    *bool
+	quiet      *bool
+}
+
+func addConnFlags(fs *flag.FlagSet) *connFlags {
+	return &connFlags{
+		server:     fs.String("s", "", "server address (default: last used, then "+defaultServerAddress+")"),
+		caCert:     fs.String("ca-cert", "", "CA certificate for verifying the server"),
+		insecure:   fs.Bool("insecure", false, "disable TLS (development only)"),
+		offline:    fs.Bool("offline", false, "serve reads from the local cache without connecting"),
+		maxMsgSize: fs.Int("max-msg-size", 0, "max gRPC message size in bytes, matching the server (default 4MB)"),
+		retries:    fs.Int("retries", 0, "retries after transient failures, -1 to disable (default 3)"),
+		retryDelay: fs.Duration("retry-delay", 0, "base delay between retries (default 200ms)"),
+		timeout:    fs.Duration("timeout", 0, "per-call RPC timeout; sync gets a multiple (default 10s, or rpc_timeout from the config)"),
+		verbose:    fs.Bool("verbose", false, "log diagnostic detail to stderr"),
+		quiet:      fs.Bool("quiet", false, "suppress informational output; results and errors still print"),
+	}
+}
+
+func (f *connFlags) connect(c *client.Client) error {
+	applyLogFlags(*f.verbose, *f.quiet)
+	if *f.offline {
+		diag.Debug("offline mode, serving from the local cache")
+		c.SetOffline(true)
+		return nil
+	}
+	if *f.maxMsgSize > 0 {
+		c.SetMaxMessageSize(*f.maxMsgSize)
+	}
+	if *f.retries != 0 || *f.retryDelay != 0 {
+		c.SetRetryPolicy(*f.retries, *f.retryDelay)
+	}
+	if *f.timeout > 0 {
+		c.SetRPCTimeout(*f.timeout)
+	}
+	addr := c.ServerAddress(*f.server, defaultServerAddress)
+	diag.Debug("connecting", "server", addr, "tls", !*f.insecure)
+	start := time.Now()
+	if err := c.Connect(addr, *f.caCert, *f.insecure); err != nil {
+		return err
+	}
+	diag.Debug("connected", "server", addr, "elapsed", time.Since(start))
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	cfgPath, err := client.DefaultConfigPath()
+	if err != nil {
+		fatal(err)
+	}
+	c, err := client.New(cfgPath)
+	if err != nil {
+		fatal(err)
+	}
+	defer c.Close()
+
+	cmd, args := os.Args[1], os.Args[2:]
+	switch cmd {
+	case "register":
+		err = runRegister(c, args)
+	case "login":
+		err = runLogin(c, args)
+	case "add":
+		err = runAdd(c, args)
+	case "get":
+		err = runGet(c, args)
+	case "edit":
+		err = runEdit(c, args)
+	case "delete":
+		err = runDelete(c, args)
+	case "rotate":
+		err = runRotate(c, args)
+	case "list":
+		err = runList(c, args)
+	case "search":
+		err = runSearch(c, args)
+	case "stats":
+		err = runStats(c, args)
+	case "trash":
+		err = runTrash(c, args)
+	case "sync":
+		err = runSync(c, args)
+	case "history":
+		err = runHistory(c, args)
+	case "restore":
+		err = runRestore(c, args)
+	case "export":
+		err = runExport(c, args)
+	case "import":
+		err = runImport(c, args)
+	case "logout":
+		err = runLogout(c, args)
+	case "change-password":
+		err = runChangePassword(c, args)
+	case "delete-account":
+		err = runDeleteAccount(c, args)
+	case "whoami":
+		err = runWhoami(c, args)
+	case "tui":
+		err = runTUI(c, args)
+	case "profile":
+		err = runProfile(c, args)
+	case "agent":
+		err = runAgent(c, args)
+	case "unlock":
+		err = runUnlock(c, args)
+	case "lock":
+		err = runLock(c, args)
+	default:
+		usage()
+		os.Exit(1)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: client <command> [flags]
+
+commands:
+  register   create an account on the server
+  login      authenticate against the server
+  logout     revoke the current session
+  whoami     show the active account and token expiry
+  change-password  replace the account password (ends all sessions)
+  add        store a new secret
+  get        retrieve a secret by name
+  edit       overwrite an existing secret
+  delete     remove one or more secrets (-n is repeatable, -all clears the vault)
+  rotate     replace just a secret's value, keeping everything else
+  list       list stored secrets
+  search     find secrets by name substring
+  stats      summarize the vault by item type
+  trash      list deleted secrets (trash restore brings one back)
+  tui        browse the vault interactively
+  profile    manage named server profiles (add, use, list)
+  sync       pull changes from the server
+  history    list archived versions of a secret
+  restore    bring back an archived version of a secret
+  export     write an encrypted backup of the vault
+  import     restore items from an encrypted backup
+  agent      hold the master password in memory for a while (no re-prompting)
+  unlock     prompt once and start a background agent with the master password
+  lock       stop the agent and forget the cached master password
+  delete-account  permanently delete the account and all its secrets
+
+The master password may also come from the GOPHKEEPER_MASTER_PASSWORD
+environment variable; note that environment variables are visible to
+other processes run by the same user.`)
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(exitCode(err))
+}
+
+func runRegister(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("register", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	login := fs.String("u", "", "login name")
+	fs.Parse(args)
+	if *login == "" {
+		return fmt.Errorf("login is required (-u)")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	password, err := pw.read("Account password: ")
+	if err != nil {
+		return err
+	}
+	if err := c.Register(*login, password); err != nil {
+		return err
+	}
+	info("registered and logged in\n")
+	return nil
+}
+
+func runLogin(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	login := fs.String("u", "", "login name (default: last used)")
+	fs.Parse(args)
+	if *login == "" {
+		*login = c.SavedUsername()
+	}
+	if *login == "" {
+		return fmt.Errorf("login is required (-u)")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	password, err := pw.read("Account password: ")
+	if err != nil {
+		return err
+	}
+	if err := c.Login(*login, password); err != nil {
+		return err
+	}
+	info("logged in\n")
+	return nil
+}
+
+func runAdd(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	name := fs.String("name", "", "item name")
+	itemType := fs.String("type", models.TypeText, "item type (login_password, text, binary, card, totp, ssh_key)")
+	data := fs.String("data", "", "secret value (totp: the base32 seed, card: the card number)")
+	publicKey := fs.String("public-key", "", "ssh_key: public key line")
+	holder := fs.String("holder", "", "card: cardholder name")
+	expiry := fs.String("expiry", "", "card: expiry as MM/YY")
+	cvv := fs.String("cvv", "", "card: security code")
+	comment := fs.String("comment", "", "ssh_key: key comment")
+	file := fs.String("file", "", "read the secret value from a file")
+	var meta stringSliceFlag
+	fs.Var(&meta, "meta", "metadata as key=value (repeatable; commas in the value are kept)")
+	var tags stringSliceFlag
+	fs.Var(&tags, "tag", "tag for grouping items (repeatable)")
+	expiresIn := fs.Duration("expires-in", 0, "make the item expire after this long (0 means never)")
+	compress := fs.Bool("compress", false, "gzip the secret before encrypting when it saves space")
+	notes := fs.String("notes", "", "free-text note, stored encrypted with the secret")
+	update := fs.Bool("update", false, "overwrite an existing item of the same name instead of failing")
+	issuer := fs.String("issuer", "", "totp: issuing service")
+	account := fs.String("account", "", "totp: account name")
+	algorithm := fs.String("algorithm", "", "totp: SHA1 (default), SHA256 or SHA512")
+	digits := fs.Int("digits", 0, "totp: code length (default 6)")
+	period := fs.Int("period", 0, "totp: code lifetime in seconds (default 30)")
+	fs.Parse(args)
+	if *name == "" {
+		return fmt.Errorf("item name is required (-name)")
+	}
+
+	var plaintext []byte
+	switch {
+	case *file != "":
+		b, err := os.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf("read file: %w", err)
+		}
+		plaintext = b
+	case *data != "":
+		plaintext = []byte(*data)
+	default:
+		return fmt.Errorf("one of -data or -file is required")
+	}
+	if *itemType == models.TypeSSHKey {
+		passphrase, err := promptPassword("Key passphrase (empty if the key is not encrypted): ")
+		if err != nil {
+			return err
+		}
+		key, err := client.NewSSHKeyData(*name, string(plaintext), *publicKey, passphrase, *comment)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(key)
+		if err != nil {
+			return fmt.Errorf("encode ssh key data: %w", err)
+		}
+		plaintext = b
+	}
+	metadata, err := parseMetadataFlags(meta)
+	if err != nil {
+		return err
+	}
+	if *itemType == models.TypeCard {
+		card := models.CardData{Number: string(plaintext), Holder: *holder, CVV: *cvv}
+		if *expiry != "" {
+			month, year, err := parseCardExpiry(*expiry)
+			if err != nil {
+				return err
+			}
+			card.ExpiryMonth, card.ExpiryYear = month, year
+		}
+		// Validate before encrypting: a typo caught now is fixable.
+		if err := card.Validate(); err != nil {
+			return fmt.Errorf("invalid card: %w", err)
+		}
+		// The brand is derivable from the number's prefix alone, so
+		// keeping it in the clear metadata costs nothing and lets list
+		// show it without decrypting.
+		if brand := card.Brand(); brand != "" {
+			if metadata == nil {
+				metadata = map[string]string{}
+			}
+			metadata["brand"] = brand
+		}
+		b, err := json.Marshal(card)
+		if err != nil {
+			return fmt.Errorf("encode card data: %w", err)
+		}
+		plaintext = b
+	}
+	if *itemType == models.TypeTOTP {
+		b, err := json.Marshal(models.TOTPData{
+			Issuer:    *issuer,
+			Account:   *account,
+			Secret:    string(plaintext),
+			Algorithm: *algorithm,
+			Digits:    *digits,
+			Period:    *period,
+		})
+		if err != nil {
+			return fmt.Errorf("encode totp data: %w", err)
+		}
+		plaintext = b
+	}
+
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if err := unlock(c, pw); err != nil {
+		return err
+	}
+	c.SetCompression(*compress)
+	var expiresAt time.Time
+	if *expiresIn > 0 {
+		expiresAt = time.Now().Add(*expiresIn)
+	}
+	id, err := c.AddItemWithNotes(*name, *itemType, plaintext, *notes, metadata, tags, expiresAt)
+	var dup *client.DuplicateItemError
+	if errors.As(err, &dup) {
+		if !*update {
+			return fmt.Errorf("%w — use edit to change it, or re-run add with -update to overwrite", dup)
+		}
+		if err := c.UpdateItemNotes(*name, *itemType, plaintext, *notes, metadata, tags); err != nil {
+			return err
+		}
+		fmt.Printf("updated existing item %q\n", *name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("stored %q (id %d)\n", *name, id)
+	return nil
+}
+
+func runEdit(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("edit", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	name := fs.String("name", "", "item name")
+	itemType := fs.String("type", "", "new item type (empty keeps the current one)")
+	data := fs.String("data", "", "new secret value")
+	file := fs.String("file", "", "read the new secret value from a file")
+	var meta stringSliceFlag
+	fs.Var(&meta, "meta", "new metadata as key=value (repeatable; commas in the value are kept)")
+	var tags stringSliceFlag
+	fs.Var(&tags, "tag", "replacement tag (repeatable; omit to keep current tags)")
+	compress := fs.Bool("compress", false, "gzip the secret before encrypting when it saves space")
+	notes := fs.String("notes", "", "replacement note, stored encrypted (empty with the flag set removes it)")
+	fs.Parse(args)
+	notesSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "notes" {
+			notesSet = true
+		}
+	})
+	if *name == "" {
+		return fmt.Errorf("item name is required (-name)")
+	}
+
+	var plaintext []byte
+	switch {
+	case *file != "":
+		b, err := os.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf("read file: %w", err)
+		}
+		plaintext = b
+	case *data != "":
+		plaintext = []byte(*data)
+	default:
+		return fmt.Errorf("one of -data or -file is required")
+	}
+
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if err := unlock(c, pw); err != nil {
+		return err
+	}
+	c.SetCompression(*compress)
+	metadata, err := parseMetadataFlags(meta)
+	if err != nil {
+		return err
+	}
+	if notesSet {
+		err = c.UpdateItemNotes(*name, *itemType, plaintext, *notes, metadata, tags)
+	} else {
+		err = c.UpdateItem(*name, *itemType, plaintext, metadata, tags)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("updated %q\n", *name)
+	return nil
+}
+
+func runRotate(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("rotate", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	name := fs.String("n", "", "item name")
+	password := fs.String("password", "", "the new secret value")
+	generate := fs.Bool("generate", false, "generate a random password instead")
+	length := fs.Int("length", 0, "length of the generated password (default 24)")
+	fs.Parse(args)
+	if *name == "" {
+		return fmt.Errorf("item name is required (-n)")
+	}
+	if (*password == "") == !*generate {
+		return fmt.Errorf("exactly one of -password or -generate is required")
+	}
+	newSecret := *password
+	if *generate {
+		generated, err := crypto.GeneratePassword(*length)
+		if err != nil {
+			return err
+		}
+		newSecret = generated
+	}
+
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if err := unlock(c, pw); err != nil {
+		return err
+	}
+	if err := c.RotateItem(*name, []byte(newSecret)); err != nil {
+		return err
+	}
+	if *generate {
+		fmt.Printf("rotated %q to a generated %d-character password: %s\n", *name, len(newSecret), newSecret)
+	} else {
+		fmt.Printf("rotated %q\n", *name)
+	}
+	return nil
+}
+
+func runDelete(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("delete", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	var names stringSliceFlag
+	fs.Var(&names, "n", "item name (repeatable)")
+	all := fs.Bool("all", false, "delete every item in the vault")
+	force := fs.Bool("f", false, "delete without confirmation")
+	purge := fs.Bool("purge", false, "permanently delete now instead of soft-deleting")
+	fs.Parse(args)
+	if len(names) == 0 && !*all {
+		return fmt.Errorf("item name is required (-n, or -all)")
+	}
+	if *all && len(names) > 0 {
+		return fmt.Errorf("-all and -n are mutually exclusive")
+	}
+	if *purge && (*all || len(names) > 1) {
+		return fmt.Errorf("-purge works on a single item (-n)")
+	}
+
+	if len(names) == 1 && !*all {
+		name := names[0]
+		verb := "delete"
+		if *purge {
+			verb = "permanently delete"
+		}
+		if !*force && !confirm(fmt.Sprintf("%s %q?", verb, name)) {
+			fmt.Println("aborted")
+			return nil
+		}
+		if err := conn.connect(c); err != nil {
+			return err
+		}
+		if *purge {
+			if err := c.PurgeItem(name); err != nil {
+				return err
+			}
+			fmt.Printf("purged %q\n", name)
+			return nil
+		}
+		if err := c.DeleteItem(name); err != nil {
+			return err
+		}
+		fmt.Printf("deleted %q\n", name)
+		return nil
+	}
+
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if *all {
+		names = nil
+		const pageSize = 100
+		for offset := int64(0); ; offset += pageSize {
+			items, total, err := c.ListItems(pageSize, offset, nil, nil, "", "")
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			if int64(len(names)) >= total || len(items) == 0 {
+				break
+			}
+		}
+		if len(names) == 0 {
+			fmt.Println("the vault is empty")
+			return nil
+		}
+	}
+	if !*force && !confirm(fmt.Sprintf("delete %d items?", len(names))) {
+		fmt.Println("aborted")
+		return nil
+	}
+	results, err := c.DeleteItems(names)
+	if err != nil {
+		return err
+	}
+	deleted := 0
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", r.Name, r.Err)
+			continue
+		}
+		deleted++
+	}
+	fmt.Printf("deleted %d of %d items\n", deleted, len(results))
+	return nil
+}
+
+// confirm asks the user a yes/no question on stderr and returns true
+// only on an explicit yes.
+func confirm(prompt string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+	var answer string
+	fmt.Fscanln(os.Stdin, &answer)
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func runGet(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("get", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	name := fs.String("name", "", "item name")
+	clip := fs.Bool("clipboard", false, "copy the secret to the clipboard instead of printing it")
+	clipTimeout := fs.Duration("clip-timeout", client.DefaultClipboardTimeout, "clear the clipboard after this long")
+	out := fs.String("out", "", "ssh_key: write the private key to this file instead of printing it")
+	output := fs.String("output", "", `output format: "json" for machine-readable output`)
+	redact := fs.Bool("redact", false, "with -output json: mask passwords and card numbers")
+	fs.Parse(args)
+	if *name == "" {
+		return fmt.Errorf("item name is required (-name)")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if err := unlock(c, pw); err != nil {
+		return err
+	}
+	item, plaintext, err := c.GetItem(*name)
+	if err != nil {
+		return err
+	}
+	if *output == "json" {
+		b, err := client.MarshalItemJSON(item, plaintext, *redact)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	fmt.Printf("%s (%s, version %d)\n", item.Name, item.Type, item.Version)
+	for k, v := range item.Metadata {
+		fmt.Printf("  %s: %s\n", k, v)
+	}
+	if item.Notes != "" {
+		fmt.Printf("  notes: %s\n", item.Notes)
+	}
+	if item.Type == models.TypeSSHKey {
+		var key models.SSHKeyData
+		if err := json.Unmarshal(plaintext, &key); err != nil {
+			return fmt.Errorf("parse ssh key data: %w", err)
+		}
+		if key.Comment != "" {
+			fmt.Printf("  comment: %s\n", key.Comment)
+		}
+		if key.PublicKey != "" {
+			fmt.Println(key.PublicKey)
+		}
+		if *out != "" {
+			if err := os.WriteFile(*out, []byte(key.PrivateKey), 0o600); err != nil {
+				return fmt.Errorf("write private key: %w", err)
+			}
+			fmt.Printf("private key written to %s\n", *out)
+			return nil
+		}
+		plaintext = []byte(key.PrivateKey)
+	}
+	if item.Type == models.TypeCard {
+		var card models.CardData
+		if err := json.Unmarshal(plaintext, &card); err == nil {
+			line := card.Masked()
+			if brand := card.Brand(); brand != "" {
+				line = brand + " " + line
+			}
+			if card.ExpiryMonth != 0 {
+				line += fmt.Sprintf(" (exp %02d/%02d)", card.ExpiryMonth, card.ExpiryYear%100)
+			}
+			fmt.Printf("  %s\n", line)
+			if card.Holder != "" {
+				fmt.Printf("  holder: %s\n", card.Holder)
+			}
+			// The full number is what gets printed or copied below.
+			plaintext = []byte(card.Number)
+		}
+		// Cards stored before structured payloads are plain numbers;
+		// print them as-is.
+	}
+	if item.Type == models.TypeTOTP {
+		var totp models.TOTPData
+		if err := json.Unmarshal(plaintext, &totp); err != nil {
+			return fmt.Errorf("parse totp data: %w", err)
+		}
+		if totp.Issuer != "" || totp.Account != "" {
+			fmt.Printf("  %s: %s\n", totp.Issuer, totp.Account)
+		}
+		now := time.Now()
+		code, err := crypto.GenerateTOTP(totp.Secret, totp.Algorithm, totp.Digits, totp.Period, now)
+		if err != nil {
+			return err
+		}
+		plaintext = []byte(code)
+		fmt.Printf("code valid for %d more seconds\n", crypto.TOTPRemaining(totp.Period, now))
+	}
+	if *clip {
+		cb, err := client.SystemClipboard()
+		if err == nil {
+			fmt.Fprintf(os.Stderr, "copied to the clipboard; clearing in %s\n", *clipTimeout)
+			return client.CopySecret(cb, string(plaintext), *clipTimeout)
+		}
+		fmt.Fprintf(os.Stderr, "%v; printing instead\n", err)
+	}
+	fmt.Println(string(plaintext))
+	return nil
+}
+
+func runList(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	limit := fs.Int64("limit", 20, "items per page")
+	page := fs.Int64("page", 1, "page number, starting at 1")
+	var filters stringSliceFlag
+	fs.Var(&filters, "filter", "metadata filter as key=value (repeatable)")
+	var tags stringSliceFlag
+	fs.Var(&tags, "tag", "keep only items carrying this tag (repeatable)")
+	output := fs.String("output", "", `output format: "json" for machine-readable output`)
+	sortBy := fs.String("sort", "", `sort field: "name", "created", "updated" or "type" (default creation order)`)
+	order := fs.String("order", "", `sort direction: "asc" or "desc"`)
+	staleAfter := fs.Duration("stale-after", 0, "mark items not updated for this long as stale (0 disables)")
+	staleOnly := fs.Bool("stale-only", false, "with -stale-after: list only the stale items")
+	fs.Parse(args)
+	if *page < 1 {
+		return fmt.Errorf("page must be 1 or higher")
+	}
+	if *staleOnly && *staleAfter <= 0 {
+		return fmt.Errorf("-stale-only needs -stale-after")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	filter, err := parseMetadataFlags(filters)
+	if err != nil {
+		return err
+	}
+	items, total, err := c.ListItems(*limit, (*page-1)*(*limit), filter, tags, *sortBy, *order)
+	if err != nil {
+		return err
+	}
+	if *output == "json" {
+		b, err := client.MarshalItemsJSON(items)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	now := time.Now()
+	for _, item := range items {
+		stale := item.Stale(*staleAfter, now)
+		if *staleOnly && !stale {
+			continue
+		}
+		line := fmt.Sprintf("%-6d %-30s %s", item.ID, item.Name, item.Type)
+		if brand := item.Metadata["brand"]; brand != "" {
+			line += " (" + brand + ")"
+		}
+		if stale {
+			line += fmt.Sprintf("  [stale: last updated %s]", item.UpdatedAt.Local().Format(time.DateOnly))
+		}
+		fmt.Println(line)
+	}
+	if *limit > 0 {
+		pages := (total + *limit - 1) / *limit
+		fmt.Printf("page %d of %d (%d items total)\n", *page, pages, total)
+	}
+	return nil
+}
+
+func runSearch(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	query := fs.String("q", "", "name substring to search for")
+	fs.Parse(args)
+	if *query == "" {
+		return fmt.Errorf("search query is required (-q)")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	items, err := c.SearchItems(*query)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		fmt.Println("no items found")
+		return nil
+	}
+	for _, item := range items {
+		fmt.Printf("%-6d %-30s %s\n", item.ID, item.Name, item.Type)
+	}
+	return nil
+}
+
+func runTrash(c *client.Client, args []string) error {
+	if len(args) > 0 && args[0] == "restore" {
+		fs := flag.NewFlagSet("trash restore", flag.ExitOnError)
+		conn := addConnFlags(fs)
+		id := fs.Int64("id", 0, "id of the deleted item, as shown by trash")
+		fs.Parse(args[1:])
+		if *id == 0 {
+			return fmt.Errorf("item id is required (-id)")
+		}
+		if err := conn.connect(c); err != nil {
+			return err
+		}
+		item, err := c.RestoreDeleted(*id)
+		if err != nil {
+			return err
+		}
+		info("restored %q (version %d)\n", item.Name, item.Version)
+		return nil
+	}
+
+	fs := flag.NewFlagSet("trash", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	fs.Parse(args)
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	items, err := c.ListTrash()
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		info("the trash is empty\n")
+		return nil
+	}
+	for _, item := range items {
+		fmt.Printf("%-6d %-30s %-15s deleted %s\n",
+			item.ID, item.Name, item.Type, item.DeletedAt.Local().Format(time.DateOnly))
+	}
+	return nil
+}
+
+func runStats(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	fs.Parse(args)
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	stats, err := c.Stats()
+	if err != nil {
+		return err
+	}
+	if len(stats) == 0 {
+		info("the vault is empty\n")
+		return nil
+	}
+	var count, size int64
+	var oldest, newest time.Time
+	for _, st := range stats {
+		fmt.Printf("%-15s %5d items  %8d bytes\n", st.Type, st.Count, st.TotalSize)
+		count += st.Count
+		size += st.TotalSize
+		if oldest.IsZero() || st.Oldest.Before(oldest) {
+			oldest = st.Oldest
+		}
+		if st.Newest.After(newest) {
+			newest = st.Newest
+		}
+	}
+	fmt.Printf("%-15s %5d items  %8d bytes\n", "total", count, size)
+	fmt.Printf("oldest item %s, newest %s\n",
+		oldest.Local().Format(time.DateOnly), newest.Local().Format(time.DateOnly))
+	return nil
+}
+
+func runSync(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	dryRun := fs.Bool("dry-run", false, "report what would change without applying anything")
+	fs.Parse(args)
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if *dryRun {
+		plan, err := c.PreviewSync(nil)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("would push %d new and %d updated items, pull %d changes; %d conflicts\n",
+			plan.Adds, plan.Updates, plan.Pulls, plan.Conflicts)
+		return nil
+	}
+	result, err := c.Sync(nil)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d items changed on the server\n", len(result.Items))
+	for _, conflict := range result.Conflicts {
+		fmt.Printf("conflict: %q changed both locally (version %d) and on the server (version %d)\n",
+			conflict.Server.Name, conflict.Local.Version, conflict.Server.Version)
+	}
+	return nil
+}
+
+func runHistory(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	name := fs.String("n", "", "item name")
+	fs.Parse(args)
+	if *name == "" {
+		return fmt.Errorf("item name is required (-n)")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	versions, err := c.ItemHistory(*name)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		fmt.Printf("%q has no archived versions\n", *name)
+		return nil
+	}
+	for _, v := range versions {
+		fmt.Printf("version %d  archived %s\n", v.Version, v.ArchivedAt.Local().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func runRestore(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	name := fs.String("n", "", "item name")
+	version := fs.Int64("version", 0, "archived version number to restore")
+	fs.Parse(args)
+	if *name == "" {
+		return fmt.Errorf("item name is required (-n)")
+	}
+	if *version <= 0 {
+		return fmt.Errorf("archived version number is required (-version)")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if err := c.RestoreItem(*name, *version); err != nil {
+		return err
+	}
+	fmt.Printf("restored %q to version %d\n", *name, *version)
+	return nil
+}
+
+func runExport(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	out := fs.String("o", "", "output file")
+	fs.Parse(args)
+	if *out == "" {
+		return fmt.Errorf("output file is required (-o)")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	count, err := c.Export(*out)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("exported %d items to %s\n", count, *out)
+	return nil
+}
+
+func runImport(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	in := fs.String("i", "", "backup file")
+	overwrite := fs.Bool("overwrite", false, "replace items that already exist")
+	dryRun := fs.Bool("dry-run", false, "report what would change without applying anything")
+	fs.Parse(args)
+	if *in == "" {
+		return fmt.Errorf("backup file is required (-i)")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if *dryRun {
+		plan, err := c.PreviewImport(*in, *overwrite)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("would add %d items, update %d, skip %d\n", plan.Add, plan.Update, plan.Skip)
+		return nil
+	}
+	applied, skipped, err := c.Import(*in, *overwrite)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d items, skipped %d\n", applied, skipped)
+	return nil
+}
+
+func runLogout(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("logout", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	fs.Parse(args)
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if err := c.Logout(); err != nil {
+		return err
+	}
+	info("logged out\n")
+	return nil
+}
+
+func runChangePassword(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("change-password", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	fs.Parse(args)
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	current, newPass, err := readPasswordChange(pw)
+	if err != nil {
+		return err
+	}
+	if err := c.ChangePassword(current, newPass); err != nil {
+		return err
+	}
+	info("password changed; all sessions were ended, log in again\n")
+	return nil
+}
+
+// readPasswordChange collects the current and new account passwords.
+// With -password-stdin or -password-file both come from the same
+// source, one per line; interactively the new password is asked for
+// twice.
+func readPasswordChange(pw *passwordFlags) (current, newPass string, err error) {
+	switch {
+	case *pw.stdin, *pw.file != "":
+		r := passwordInput
+		if *pw.file != "" {
+			file, err := os.Open(*pw.file)
+			if err != nil {
+				return "", "", fmt.Errorf("read password file: %w", err)
+			}
+			defer file.Close()
+			r = file
+		}
+		scanner := bufio.NewScanner(r)
+		for _, dst := range []*string{&current, &newPass} {
+			if !scanner.Scan() {
+				return "", "", fmt.Errorf("read passwords: want two lines, current then new")
+			}
+			*dst = strings.TrimRight(scanner.Text(), "\r")
+		}
+		return current, newPass, nil
+	default:
+		if current, err = promptPassword("Current account password: "); err != nil {
+			return "", "", err
+		}
+		if newPass, err = promptPassword("New account password: "); err != nil {
+			return "", "", err
+		}
+		confirm, err := promptPassword("Repeat new account password: ")
+		if err != nil {
+			return "", "", err
+		}
+		if confirm != newPass {
+			return "", "", fmt.Errorf("new passwords do not match")
+		}
+		return current, newPass, nil
+	}
+}
+
+func runDeleteAccount(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("delete-account", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	fs.Parse(args)
+
+	// Deleting an account destroys every stored secret with no way
+	// back, so the confirmation is deliberately hard to type on reflex.
+	fmt.Fprint(os.Stderr, "This permanently deletes the account and ALL its secrets.\n")
+	fmt.Fprint(os.Stderr, `Type "delete my account" to continue: `)
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	if strings.TrimSpace(answer) != "delete my account" {
+		fmt.Println("aborted")
+		return nil
+	}
+
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	password, err := pw.read("Account password: ")
+	if err != nil {
+		return err
+	}
+	if err := c.DeleteAccount(password); err != nil {
+		return err
+	}
+	fmt.Println("account deleted")
+	return nil
+}
+
+func runAgent(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("agent", flag.ExitOnError)
+	pw := addPasswordFlags(fs)
+	ttl := fs.Duration("ttl", client.DefaultAgentTTL, "idle timeout before the agent forgets the password")
+	fs.Parse(args)
+	pass, err := pw.read("Master password: ")
+	if err != nil {
+		return err
+	}
+	if err := c.VerifyMasterPassword(pass); err != nil {
+		return err
+	}
+	path, err := client.AgentSocketPath()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "agent holding the master password for %s\n", *ttl)
+	return client.ServeAgent(path, pass, *ttl)
+}
+
+// runUnlock verifies the master password once and leaves a background
+// agent serving it, so following commands skip the prompt until the
+// idle timeout or an explicit lock.
+func runUnlock(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("unlock", flag.ExitOnError)
+	pw := addPasswordFlags(fs)
+	ttl := fs.Duration("ttl", client.DefaultAgentTTL, "idle timeout before the agent forgets the password")
+	fs.Parse(args)
+	pass, err := pw.read("Master password: ")
+	if err != nil {
+		return err
+	}
+	if err := c.VerifyMasterPassword(pass); err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate executable: %w", err)
+	}
+	agent := exec.Command(exe, "agent", "-password-stdin", "-ttl", ttl.String())
+	agent.Stdin = strings.NewReader(pass + "\n")
+	if err := agent.Start(); err != nil {
+		return fmt.Errorf("start agent: %w", err)
+	}
+	if err := agent.Process.Release(); err != nil {
+		return fmt.Errorf("detach agent: %w", err)
+	}
+	info("unlocked for %s of inactivity\n", *ttl)
+	return nil
+}
+
+func runLock(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("lock", flag.ExitOnError)
+	fs.Parse(args)
+	path, err := client.AgentSocketPath()
+	if err != nil {
+		return err
+	}
+	if err := client.StopAgent(path); err != nil {
+		info("already locked\n")
+		return nil
+	}
+	info("locked\n")
+	return nil
+}
+
+func runWhoami(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("whoami", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	fs.Parse(args)
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	profile, err := c.Whoami()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s (user id %d)\n", profile.Login, profile.UserID)
+	if !profile.LastLoginAt.IsZero() {
+		from := ""
+		if profile.LastLoginIP != "" {
+			from = " from " + profile.LastLoginIP
+		}
+		fmt.Printf("last login %s%s\n", profile.LastLoginAt.Format(time.RFC3339), from)
+	}
+	if until := time.Until(profile.TokenExpiresAt); until > 0 {
+		fmt.Printf("token expires %s (in %s)\n",
+			profile.TokenExpiresAt.Format(time.RFC3339), until.Round(time.Second))
+	} else {
+		fmt.Printf("token EXPIRED %s ago\n", (-until).Round(time.Second))
+	}
+	return nil
+}
+
+// unlock prompts for the master password and verifies it before any
+// encryption or decryption happens.
+// masterPasswordEnv optionally supplies the master password for
+// scripted use. Setting it opts in to keeping the password in the
+// process environment, which is less secure than the prompt or agent.
+const masterPasswordEnv = "GOPHKEEPER_MASTER_PASSWORD"
+
+func runProfile(c *client.Client, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: profile add|use|list [flags]")
+	}
+	sub, rest := args[0], args[1:]
+	switch sub {
+	case "add":
+		fs := flag.NewFlagSet("profile add", flag.ExitOnError)
+		name := fs.String("name", "", "profile name")
+		addr := fs.String("s", "", "server address for this profile")
+		fs.Parse(rest)
+		if *name == "" {
+			return fmt.Errorf("profile name is required (-name)")
+		}
+		if err := c.AddProfile(*name, *addr); err != nil {
+			return err
+		}
+		info("profile %q added — activate it with: profile use -name %s\n", *name, *name)
+		return nil
+	case "use":
+		fs := flag.NewFlagSet("profile use", flag.ExitOnError)
+		name := fs.String("name", "", "profile name")
+		fs.Parse(rest)
+		if *name == "" {
+			return fmt.Errorf("profile name is required (-name)")
+		}
+		if err := c.UseProfile(*name); err != nil {
+			return err
+		}
+		info("switched to profile %q\n", *name)
+		return nil
+	case "list":
+		names, current := c.Profiles()
+		if len(names) == 0 {
+			info("no profiles configured\n")
+			return nil
+		}
+		for _, name := range names {
+			marker := "  "
+			if name == current {
+				marker = "* "
+			}
+			addr, username, _ := c.ProfileInfo(name)
+			line := fmt.Sprintf("%s%-15s %s", marker, name, addr)
+			if username != "" {
+				line += " (" + username + ")"
+			}
+			fmt.Println(line)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown profile subcommand %q (want add, use or list)", sub)
+}
+
+func unlock(c *client.Client, pw *passwordFlags) error {
+	pass, err := resolveMasterPassword(pw)
+	if err != nil {
+		return err
+	}
+	return c.VerifyMasterPassword(pass)
+}
+
+// resolveMasterPassword tries the environment, then a running agent,
+// and only then prompts.
+func resolveMasterPassword(pw *passwordFlags) (string, error) {
+	if pass, ok := os.LookupEnv(masterPasswordEnv); ok {
+		return pass, nil
+	}
+	if path, err := client.AgentSocketPath(); err == nil {
+		if pass, err := client.AskAgent(path); err == nil {
+			return pass, nil
+		}
+	}
+	return pw.read("Master password: ")
+}
+
+func promptPassword(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	return string(b), nil
+}
+
+// passwordFlags select where a password comes from. The default is an
+// interactive terminal prompt; -password-stdin and -password-file keep
+// the secret out of argv, shell history and process listings.
+type passwordFlags struct {
+	stdin *bool
+	file  *string
+}
+
+func addPasswordFlags(fs *flag.FlagSet) *passwordFlags {
+	return &passwordFlags{
+		stdin: fs.Bool("password-stdin", false, "read the password from standard input instead of prompting"),
+		file:  fs.String("password-file", "", "read the password from the first line of this file"),
+	}
+}
+
+// passwordInput is what -password-stdin reads from; tests swap it out.
+var passwordInput io.Reader = os.Stdin
+
+func (f *passwordFlags) read(prompt string) (string, error) {
+	switch {
+	case *f.stdin:
+		return readPasswordLine(passwordInput)
+	case *f.file != "":
+		file, err := os.Open(*f.file)
+		if err != nil {
+			return "", fmt.Errorf("read password file: %w", err)
+		}
+		defer file.Close()
+		return readPasswordLine(file)
+	default:
+		return promptPassword(prompt)
+	}
+}
+
+// readPasswordLine returns the first line of r without the trailing
+// newline.
+func readPasswordLine(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("read password: %w", err)
+		}
+		return "", fmt.Errorf("read password: empty input")
+	}
+	return strings.TrimRight(scanner.Text(), "\r"), nil
+}
+
+// stringSliceFlag collects repeated flag values.
+type stringSliceFlag []string
+
+func (f *stringSliceFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringSliceFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// parseCardExpiry parses "MM/YY" (or "MM/YYYY") into month and year.
+func parseCardExpiry(s string) (month, year int, err error) {
+	if _, err := fmt.Sscanf(s, "%d/%d", &month, &year); err != nil {
+		return 0, 0, fmt.Errorf("expiry must be MM/YY, got %q", s)
+	}
+	return month, year, nil
+}
+
+// parseMetadataFlags merges repeated key=value flag occurrences into
+// one map. Malformed pairs are an error rather than silently dropped,
+// so a typo does not store an item with less metadata than the user
+// thinks. For backward compatibility one occurrence may carry several
+// comma-separated pairs; when a comma-separated segment has no '=' of
+// its own the occurrence is treated as a single pair, so values
+// containing commas work as long as each such value gets its own flag.
+func parseMetadataFlags(values []string) (map[string]string, error) {
+	var meta map[string]string
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if meta == nil {
+			meta = map[string]string{}
+		}
+		segments := strings.Split(value, ",")
+		multiPair := len(segments) > 1
+		for _, segment := range segments {
+			if !strings.Contains(segment, "=") {
+				multiPair = false
+				break
+			}
+		}
+		if !multiPair {
+			segments = []string{value}
+		}
+		for _, pair := range segments {
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("metadata pair %q is missing '=', want key=value", pair)
+			}
+			key := strings.TrimSpace(parts[0])
+			if key == "" {
+				return nil, fmt.Errorf("metadata pair %q has an empty key, want key=value", pair)
+			}
+			meta[key] = strings.TrimSpace(parts[1])
+		}
+	}
+	return meta, nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/client"
+)
+
+func TestParseMetadataFlags(t *testing.T) {
+	// One occurrence may carry several comma-separated pairs.
+	got, err := parseMetadataFlags([]string{"site=example.com,note=work account"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"site": "example.com", "note": "work account"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseMetadataFlagsEmpty(t *testing.T) {
+	for _, in := range [][]string{nil, {}, {""}} {
+		if got, err := parseMetadataFlags(in); err != nil || got != nil {
+			t.Fatalf("%v: got %v, %v, want nil map and no error", in, got, err)
+		}
+	}
+}
+
+func TestParseMetadataFlagsCommaInValue(t *testing.T) {
+	// A value containing commas keeps them when the pair has its own
+	// flag occurrence.
+	got, err := parseMetadataFlags([]string{"site=example.com", "note=hello, world"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"site": "example.com", "note": "hello, world"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseMetadataFlagsRejectsMalformedPairs(t *testing.T) {
+	for _, in := range []string{"site", "website example.com", "=value", " =value"} {
+		if _, err := parseMetadataFlags([]string{in}); err == nil {
+			t.Fatalf("%q was accepted", in)
+		}
+	}
+}
+
+func TestReadPasswordLine(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"secret\n", "secret"},
+		{"secret\r\n", "secret"},
+		{"no newline", "no newline"},
+	}
+	for _, tt := range tests {
+		got, err := readPasswordLine(strings.NewReader(tt.in))
+		if err != nil {
+			t.Fatalf("%q: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%q: got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if _, err := readPasswordLine(strings.NewReader("")); err == nil {
+		t.Fatal("empty input was accepted")
+	}
+}
+
+func TestPasswordFlagsStdin(t *testing.T) {
+	old := passwordInput
+	passwordInput = strings.NewReader("from-stdin\n")
+	defer func() { passwordInput = old }()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	pw := addPasswordFlags(fs)
+	if err := fs.Parse([]string{"-password-stdin"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := pw.read("Password: ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "from-stdin" {
+		t.Fatalf("got %q, want %q", got, "from-stdin")
+	}
+}
+
+func TestPasswordFlagsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pw")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	pw := addPasswordFlags(fs)
+	if err := fs.Parse([]string{"-password-file", path}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := pw.read("Password: ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "from-file" {
+		t.Fatalf("got %q, want %q", got, "from-file")
+	}
+}
+
+func TestResolveMasterPasswordFromEnv(t *testing.T) {
+	t.Setenv(masterPasswordEnv, "from-env")
+
+	// With the variable set, neither the agent nor the prompt is
+	// consulted, so this returns immediately.
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	pw := addPasswordFlags(fs)
+	got, err := resolveMasterPassword(pw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "from-env" {
+		t.Fatalf("got %q, want %q", got, "from-env")
+	}
+}
+
+func TestResolveMasterPasswordFromAgent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path, err := client.AgentSocketPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go client.ServeAgent(path, "from-agent", time.Minute)
+	defer client.StopAgent(path)
+	for i := 0; i < 50; i++ {
+		if _, err := client.AskAgent(path); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	pw := addPasswordFlags(fs)
+	got, err := resolveMasterPassword(pw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "from-agent" {
+		t.Fatalf("got %q, want %q", got, "from-agent")
+	}
+}
+
+func TestQuietSuppressesInfoLines(t *testing.T) {
+	defer func(w io.Writer) { infoOut = w }(infoOut)
+	var buf bytes.Buffer
+	infoOut = &buf
+
+	applyLogFlags(false, false)
+	infoOut = &buf // applyLogFlags leaves infoOut alone unless quiet
+	info("logged in\n")
+	if buf.String() != "logged in\n" {
+		t.Fatalf("info line not printed: %q", buf.String())
+	}
+
+	applyLogFlags(false, true)
+	info("should be suppressed\n")
+	if buf.String() != "logged in\n" {
+		t.Fatalf("quiet mode still printed: %q", buf.String())
+	}
+
+	// Results bypass infoOut entirely, so quiet never hides them.
+	if infoOut != io.Discard {
+		t.Fatal("quiet mode did not discard informational output")
+	}
+}
+
+func TestVerboseEnablesDebugDiagnostics(t *testing.T) {
+	defer applyLogFlags(false, false)
+
+	applyLogFlags(false, false)
+	if diag.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatal("debug diagnostics on by default")
+	}
+	applyLogFlags(true, false)
+	if !diag.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatal("verbose did not enable debug diagnostics")
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+// The TUI is a small Elm-style loop: a model, an update function fed
+// key events, and a view rendered with ANSI escapes. A framework like
+// bubbletea would buy nicer plumbing, but is a heavy dependency for a
+// list-and-reveal screen; the model/update split keeps the logic
+// testable either way.
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/ar11/gophkeeper/internal/client"
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// tuiMaxItems caps how many items the browse screen loads. Vaults
+// larger than a screenful of pages are better served by search.
+const tuiMaxItems = 1000
+
+// tuiModel holds the browse screen's state. fetch and copyTo are
+// injected so tests can drive update without a server or a clipboard.
+type tuiModel struct {
+	items  []*models.Item
+	cursor int
+	reveal bool
+	secret string
+	status string
+	quit   bool
+
+	// fetch returns the decrypted secret of the named item.
+	fetch func(name string) (string, error)
+	// copyTo puts secret on the clipboard and returns a status line.
+	copyTo func(secret string) (string, error)
+}
+
+// update advances the model by one key event. Moving the selection
+// hides a revealed secret so it never lingers next to the wrong item.
+func (m *tuiModel) update(key string) {
+	m.status = ""
+	switch key {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		m.hideSecret()
+	case "down", "j":
+		if m.cursor < len(m.items)-1 {
+			m.cursor++
+		}
+		m.hideSecret()
+	case "enter", "r":
+		if m.reveal {
+			m.hideSecret()
+			return
+		}
+		if len(m.items) == 0 {
+			return
+		}
+		secret, err := m.fetch(m.items[m.cursor].Name)
+		if err != nil {
+			m.status = err.Error()
+			return
+		}
+		m.secret, m.reveal = secret, true
+	case "c":
+		if len(m.items) == 0 {
+			return
+		}
+		secret, err := m.fetch(m.items[m.cursor].Name)
+		if err != nil {
+			m.status = err.Error()
+			return
+		}
+		status, err := m.copyTo(secret)
+		if err != nil {
+			m.status = err.Error()
+			return
+		}
+		m.status = status
+	case "q", "esc":
+		m.quit = true
+	}
+}
+
+func (m *tuiModel) hideSecret() {
+	m.secret, m.reveal = "", false
+}
+
+// view renders the model. Lines end in \r\n because the terminal is in
+// raw mode while the TUI runs.
+func (m *tuiModel) view(w io.Writer) {
+	fmt.Fprintf(w, "gophkeeper — %d items   up/down move  enter reveal  c copy  q quit\r\n\r\n", len(m.items))
+	for i, item := range m.items {
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
+		}
+		fmt.Fprintf(w, "%s%-30s %s\r\n", marker, item.Name, item.Type)
+	}
+	if m.reveal {
+		fmt.Fprintf(w, "\r\n%s\r\n", m.secret)
+	}
+	if m.status != "" {
+		fmt.Fprintf(w, "\r\n%s\r\n", m.status)
+	}
+}
+
+// readKey decodes one key press, folding the arrow-key escape
+// sequences into the names update understands.
+func readKey(in *bufio.Reader) (string, error) {
+	b, err := in.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	switch b {
+	case '\r', '\n':
+		return "enter", nil
+	case 0x03: // ctrl-c
+		return "q", nil
+	case 0x1b:
+		if in.Buffered() == 0 {
+			return "esc", nil
+		}
+		if next, _ := in.ReadByte(); next == '[' {
+			switch dir, _ := in.ReadByte(); dir {
+			case 'A':
+				return "up", nil
+			case 'B':
+				return "down", nil
+			}
+		}
+		return "esc", nil
+	}
+	return string(b), nil
+}
+
+func runTUI(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("tui", flag.ExitOnError)
+	conn := addConnFlags(fs)
+	pw := addPasswordFlags(fs)
+	clipTimeout := fs.Duration("clip-timeout", client.DefaultClipboardTimeout, "clear the clipboard after this long")
+	fs.Parse(args)
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("tui needs an interactive terminal")
+	}
+	if err := conn.connect(c); err != nil {
+		return err
+	}
+	if err := unlock(c, pw); err != nil {
+		return err
+	}
+	items, _, err := c.ListItems(tuiMaxItems, 0, nil, nil, "", "")
+	if err != nil {
+		return err
+	}
+
+	m := &tuiModel{
+		items: items,
+		fetch: func(name string) (string, error) {
+			_, plaintext, err := c.GetItem(name)
+			if err != nil {
+				return "", err
+			}
+			return string(plaintext), nil
+		},
+		copyTo: func(secret string) (string, error) {
+			cb, err := client.SystemClipboard()
+			if err != nil {
+				return "", err
+			}
+			// The timed clear runs while the TUI stays open.
+			go client.CopySecret(cb, secret, *clipTimeout)
+			return fmt.Sprintf("copied to the clipboard; clearing in %s", *clipTimeout), nil
+		},
+	}
+
+	old, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("enter raw mode: %w", err)
+	}
+	defer term.Restore(fd, old)
+	in := bufio.NewReader(os.Stdin)
+	for !m.quit {
+		fmt.Print("\x1b[2J\x1b[H")
+		m.view(os.Stdout)
+		key, err := readKey(in)
+		if err != nil {
+			return err
+		}
+		m.update(key)
+	}
+	fmt.Print("\x1b[2J\x1b[H")
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+func newTestTUIModel(names ...string) *tuiModel {
+	items := make([]*models.Item, len(names))
+	for i, name := range names {
+		items[i] = &models.Item{Name: name, Type: models.TypeText}
+	}
+	return &tuiModel{
+		items: items,
+		fetch: func(name string) (string, error) {
+			return "secret of " + name, nil
+		},
+		copyTo: func(string) (string, error) {
+			return "copied", nil
+		},
+	}
+}
+
+func TestTUISelectionMovesAndClamps(t *testing.T) {
+	m := newTestTUIModel("a", "b", "c")
+	m.update("up")
+	if m.cursor != 0 {
+		t.Fatalf("cursor moved above the first item: %d", m.cursor)
+	}
+	m.update("down")
+	m.update("j")
+	if m.cursor != 2 {
+		t.Fatalf("cursor = %d after two moves down, want 2", m.cursor)
+	}
+	m.update("down")
+	if m.cursor != 2 {
+		t.Fatalf("cursor moved past the last item: %d", m.cursor)
+	}
+	m.update("k")
+	if m.cursor != 1 {
+		t.Fatalf("cursor = %d after moving back up, want 1", m.cursor)
+	}
+}
+
+func TestTUIRevealTogglesAndFollowsSelection(t *testing.T) {
+	m := newTestTUIModel("a", "b")
+	m.update("enter")
+	if !m.reveal || m.secret != "secret of a" {
+		t.Fatalf("reveal = %v, secret = %q", m.reveal, m.secret)
+	}
+	m.update("enter")
+	if m.reveal || m.secret != "" {
+		t.Fatal("second enter did not hide the secret")
+	}
+	m.update("r")
+	m.update("down")
+	if m.reveal || m.secret != "" {
+		t.Fatal("moving the selection left the old secret revealed")
+	}
+	m.update("r")
+	if m.secret != "secret of b" {
+		t.Fatalf("secret = %q after moving to b, want b's", m.secret)
+	}
+}
+
+func TestTUIFetchErrorShowsStatus(t *testing.T) {
+	m := newTestTUIModel("a")
+	m.fetch = func(string) (string, error) {
+		return "", fmt.Errorf("decrypt item %q: boom", "a")
+	}
+	m.update("enter")
+	if m.reveal {
+		t.Fatal("fetch failure still revealed something")
+	}
+	if m.status == "" {
+		t.Fatal("fetch failure left no status line")
+	}
+	m.update("down")
+	if m.status != "" {
+		t.Fatal("status line survived the next key press")
+	}
+}
+
+func TestTUICopySetsStatus(t *testing.T) {
+	m := newTestTUIModel("a")
+	var copied string
+	m.copyTo = func(secret string) (string, error) {
+		copied = secret
+		return "copied", nil
+	}
+	m.update("c")
+	if copied != "secret of a" {
+		t.Fatalf("copied %q, want the selected secret", copied)
+	}
+	if m.status != "copied" {
+		t.Fatalf("status = %q, want copied", m.status)
+	}
+}
+
+func TestTUIQuit(t *testing.T) {
+	m := newTestTUIModel("a")
+	m.update("q")
+	if !m.quit {
+		t.Fatal("q did not quit")
+	}
+}
+
+func TestTUIEmptyVault(t *testing.T) {
+	m := newTestTUIModel()
+	m.update("down")
+	m.update("enter")
+	m.update("c")
+	if m.reveal || m.quit {
+		t.Fatal("empty vault reacted to reveal/copy keys")
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// configKeys maps config-file keys to their flag, plus the environment
+// variable (if any) that outranks the file for that setting. The
+// resulting precedence is: file < environment < command line.
+var configKeys = []struct{ key, flagName, env string }{
+	{"address", "a", "SERVER_ADDRESS"},
+	{"dsn", "d", "DATABASE_DSN"},
+	{"token_secret", "s", "TOKEN_SECRET"},
+	{"tls_cert", "tls-cert", "TLS_CERT_FILE"},
+	{"tls_key", "tls-key", "TLS_KEY_FILE"},
+	{"jwt_private_key", "jwt-private-key", "JWT_PRIVATE_KEY_FILE"},
+	{"jwt_public_key", "jwt-public-key", "JWT_PUBLIC_KEY_FILE"},
+	{"token_duration", "token-duration", ""},
+	{"min_password_len", "min-password-len", ""},
+	{"bcrypt_cost", "bcrypt-cost", ""},
+	{"login_attempts", "login-attempts", ""},
+	{"login_window", "login-window", ""},
+	{"rate_limit", "rate-limit", ""},
+	{"rate_burst", "rate-burst", ""},
+	{"max_upload_size", "max-upload-size", ""},
+	{"db_max_open_conns", "db-max-open-conns", ""},
+	{"db_max_idle_conns", "db-max-idle-conns", ""},
+	{"db_conn_max_lifetime", "db-conn-max-lifetime", ""},
+	{"max_msg_size", "max-msg-size", ""},
+	{"shutdown_timeout", "shutdown-timeout", ""},
+	{"cleanup_interval", "cleanup-interval", ""},
+	{"purge_interval", "purge-interval", ""},
+	{"purge_retention", "purge-retention", ""},
+	{"health_addr", "health-addr", "HEALTH_ADDRESS"},
+	{"metrics_addr", "metrics-addr", "METRICS_ADDRESS"},
+	{"log_level", "log-level", "LOG_LEVEL"},
+	{"log_json", "log-json", ""},
+}
+
+// parseConfigFile reads a flat "key: value" config file — the YAML
+// subset that covers simple scalar settings. Blank lines and lines
+// starting with # are ignored; values may be quoted.
+func parseConfigFile(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\", got %q", line, text)
+		}
+		value = strings.TrimSpace(value)
+		value = strings.Trim(value, `"'`)
+		values[strings.TrimSpace(key)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// applyConfigFile fills flags in from the parsed config file, skipping
+// every setting that was given on the command line or through its
+// environment variable, so those keep precedence. Unknown keys are an
+// error to catch typos early.
+func applyConfigFile(fs *flag.FlagSet, values map[string]string, lookupEnv func(string) (string, bool)) error {
+	onCmdline := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { onCmdline[f.Name] = true })
+
+	known := make(map[string]bool, len(configKeys))
+	for _, k := range configKeys {
+		known[k.key] = true
+	}
+	for key := range values {
+		if !known[key] {
+			return fmt.Errorf("unknown config key %q", key)
+		}
+	}
+
+	for _, k := range configKeys {
+		value, ok := values[k.key]
+		if !ok || onCmdline[k.flagName] {
+			continue
+		}
+		if k.env != "" {
+			if _, set := lookupEnv(k.env); set {
+				continue
+			}
+		}
+		if err := fs.Set(k.flagName, value); err != nil {
+			return fmt.Errorf("config key %q: %v", k.key, err)
+		}
+	}
+	return nil
+}
+
+// loadConfigFile applies the config file at path to the command-line
+// flags, honouring the file < environment < flag precedence.
+func loadConfigFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open config: %w", err)
+	}
+	defer f.Close()
+	values, err := parseConfigFile(f)
+	if err != nil {
+		return fmt.Errorf("parse config %s: %w", path, err)
+	}
+	return applyConfigFile(flag.CommandLine, values, os.LookupEnv)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigFile(t *testing.T) {
+	values, err := parseConfigFile(strings.NewReader(`
+# server settings
+address: :4400
+dsn: "postgres://db/keeper"
+log_level: debug
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"address": ":4400", "dsn": "postgres://db/keeper", "log_level": "debug"}
+	for k, v := range want {
+		if values[k] != v {
+			t.Fatalf("%s = %q, want %q", k, values[k], v)
+		}
+	}
+
+	if _, err := parseConfigFile(strings.NewReader("not a pair")); err == nil {
+		t.Fatal("malformed line was accepted")
+	}
+}
+
+func TestConfigPrecedenceFileEnvFlag(t *testing.T) {
+	env := map[string]string{"DATABASE_DSN": "dsn-from-env"}
+	lookup := func(key string) (string, bool) { v, ok := env[key]; return v, ok }
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	addr := fs.String("a", ":3200", "")
+	dsn := fs.String("d", env["DATABASE_DSN"], "")
+	logLevel := fs.String("log-level", "info", "")
+	if err := fs.Parse([]string{"-a", ":9999"}); err != nil {
+		t.Fatal(err)
+	}
+
+	file := map[string]string{
+		"address":   ":4400",    // loses to the -a flag
+		"dsn":       "dsn-file", // loses to DATABASE_DSN
+		"log_level": "debug",    // nothing else sets it: file wins
+	}
+	if err := applyConfigFile(fs, file, lookup); err != nil {
+		t.Fatal(err)
+	}
+
+	if *addr != ":9999" {
+		t.Fatalf("flag lost to the file: %q", *addr)
+	}
+	if *dsn != "dsn-from-env" {
+		t.Fatalf("env lost to the file: %q", *dsn)
+	}
+	if *logLevel != "debug" {
+		t.Fatalf("file value not applied: %q", *logLevel)
+	}
+}
+
+func TestApplyConfigFileRejectsUnknownKeys(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	err := applyConfigFile(fs, map[string]string{"adress": ":4400"}, func(string) (string, bool) { return "", false })
+	if err == nil {
+		t.Fatal("typoed key was accepted")
+	}
+}
@@ -0,0 +1,251 @@
+// The server command runs the GophKeeper gRPC server.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	"github.com/ar11/gophkeeper/internal/server"
+	"github.com/ar11/gophkeeper/internal/storage"
+	"github.com/ar11/gophkeeper/pkg/auth"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func main() {
+	addr := flag.String("a", envOr("SERVER_ADDRESS", ":3200"), "address to listen on")
+	dsn := flag.String("d", os.Getenv("DATABASE_DSN"), "postgres DSN or path to a SQLite file")
+	secret := flag.String("s", os.Getenv("TOKEN_SECRET"), "secret for signing auth tokens")
+	tlsCert := flag.String("tls-cert", os.Getenv("TLS_CERT_FILE"), "TLS certificate file")
+	tlsKey := flag.String("tls-key", os.Getenv("TLS_KEY_FILE"), "TLS private key file")
+	jwtPrivateKey := flag.String("jwt-private-key", os.Getenv("JWT_PRIVATE_KEY_FILE"), "RSA private key PEM for RS256 token signing")
+	jwtPublicKey := flag.String("jwt-public-key", os.Getenv("JWT_PUBLIC_KEY_FILE"), "RSA public key PEM for RS256 token verification")
+	tokenDuration := flag.Duration("token-duration", 0, "access token validity (default 24h)")
+	minPasswordLen := flag.Int("min-password-len", 0, "minimum account password length (default 8)")
+	bcryptCost := flag.Int("bcrypt-cost", 0, "bcrypt cost for password hashes; lower-cost hashes are upgraded on login (default 10)")
+	loginAttempts := flag.Int("login-attempts", 0, "failed logins allowed before lockout (default 5)")
+	loginWindow := flag.Duration("login-window", 0, "failed-login lockout window (default 15m)")
+	rateLimit := flag.Float64("rate-limit", 0, "sustained requests per second allowed per caller (0 disables throttling)")
+	rateBurst := flag.Int("rate-burst", 0, "request burst allowed per caller (default equals -rate-limit)")
+	maxUploadSize := flag.Int64("max-upload-size", 0, "total bytes allowed per streamed upload (default 64MB)")
+	maxMsgSize := flag.Int("max-msg-size", 0, "max gRPC message size in bytes (default 4MB)")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 0, "how long graceful shutdown waits before forcing a stop (default 30s)")
+	cleanupInterval := flag.Duration("cleanup-interval", 0, "how often to purge expired items (default 1h)")
+	purgeInterval := flag.Duration("purge-interval", 0, "how often to hard-delete soft-deleted items (default 1h)")
+	purgeRetention := flag.Duration("purge-retention", 0, "how long soft-deleted items are kept before purging (default 720h)")
+	dbMaxOpen := flag.Int("db-max-open-conns", 0, "max open database connections (default 25, negative for unlimited)")
+	dbMaxIdle := flag.Int("db-max-idle-conns", 0, "max idle database connections (default 5)")
+	dbConnLifetime := flag.Duration("db-conn-max-lifetime", 0, "max database connection lifetime (default 5m, negative for unlimited)")
+	healthAddr := flag.String("health-addr", envOr("HEALTH_ADDRESS", ""), "address for the HTTP /healthz endpoint (empty disables it)")
+	metricsAddr := flag.String("metrics-addr", envOr("METRICS_ADDRESS", ""), "address for the Prometheus /metrics endpoint (empty disables it)")
+	logLevel := flag.String("log-level", envOr("LOG_LEVEL", "info"), "log level: debug, info, warn or error")
+	logJSON := flag.Bool("log-json", false, "write logs as JSON instead of text")
+	migrateOnly := flag.Bool("migrate", false, "apply pending schema migrations and exit")
+	configPath := flag.String("config", os.Getenv("SERVER_CONFIG"), `config file with flat "key: value" settings (flags and env override it)`)
+	flag.Parse()
+
+	if *configPath != "" {
+		if err := loadConfigFile(*configPath); err != nil {
+			log.Fatalf("config: %v", err)
+		}
+	}
+
+	logger, err := newLogger(*logLevel, *logJSON)
+	if err != nil {
+		log.Fatalf("logging: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	if (*tlsCert == "") != (*tlsKey == "") {
+		log.Fatal("-tls-cert and -tls-key must be given together")
+	}
+
+	if *dsn == "" {
+		log.Fatal("database DSN is required (-d or DATABASE_DSN)")
+	}
+	if !*migrateOnly && *secret == "" && *jwtPrivateKey == "" {
+		log.Fatal("token secret or JWT key is required (-s, TOKEN_SECRET or -jwt-private-key)")
+	}
+
+	// Opening the store applies pending migrations.
+	store, err := storage.New(*dsn)
+	if err != nil {
+		log.Fatalf("storage: %v", err)
+	}
+	defer store.Close()
+
+	// Pool tuning only applies to backends with a real connection pool;
+	// SQLite keeps its single writer connection.
+	if pool, ok := store.(interface{ SetPool(storage.PoolConfig) }); ok {
+		pool.SetPool(storage.PoolConfig{
+			MaxOpenConns:    *dbMaxOpen,
+			MaxIdleConns:    *dbMaxIdle,
+			ConnMaxLifetime: *dbConnLifetime,
+		})
+	}
+
+	if *migrateOnly {
+		log.Print("migrations applied")
+		return
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listen on %s: %v", *addr, err)
+	}
+
+	tokenCfg, err := tokenConfig(*secret, *jwtPrivateKey, *jwtPublicKey, *tokenDuration)
+	if err != nil {
+		log.Fatalf("token config: %v", err)
+	}
+	srv := server.NewWithTokenConfig(store, tokenCfg)
+	srv.SetLogger(logger)
+	if *minPasswordLen > 0 {
+		srv.SetPasswordPolicy(crypto.PasswordPolicy{MinLength: *minPasswordLen})
+	}
+	if *bcryptCost > 0 {
+		srv.SetBcryptCost(*bcryptCost)
+	}
+	if *loginAttempts > 0 || *loginWindow > 0 {
+		attempts, window := *loginAttempts, *loginWindow
+		if attempts <= 0 {
+			attempts = 5
+		}
+		if window <= 0 {
+			window = 15 * time.Minute
+		}
+		srv.SetLoginRateLimit(attempts, window)
+	}
+	if *maxUploadSize > 0 {
+		srv.SetMaxUploadSize(*maxUploadSize)
+	}
+	if *rateLimit > 0 {
+		burst := *rateBurst
+		if burst <= 0 {
+			burst = int(*rateLimit)
+		}
+		srv.SetRequestRateLimit(*rateLimit, burst)
+	}
+	metrics := server.NewMetrics()
+	metrics.ObserveDBStats(store)
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(srv.UnaryInterceptors(metrics)...),
+		grpc.ChainStreamInterceptor(srv.AuthStreamInterceptor()),
+	}
+	if *maxMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(*maxMsgSize), grpc.MaxSendMsgSize(*maxMsgSize))
+	}
+	if *tlsCert != "" {
+		creds, err := credentials.NewServerTLSFromFile(*tlsCert, *tlsKey)
+		if err != nil {
+			log.Fatalf("load TLS credentials: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	} else {
+		logger.Warn("TLS is disabled, traffic is unencrypted")
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterGophKeeperServer(grpcServer, srv)
+
+	health := server.NewHealthServer(store)
+	grpc_health_v1.RegisterHealthServer(grpcServer, health)
+	if *healthAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/healthz", health)
+		go func() {
+			if err := http.ListenAndServe(*healthAddr, mux); err != nil {
+				logger.Error("healthz server failed", "error", err)
+			}
+		}()
+	}
+	if *metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		go func() {
+			if err := http.ListenAndServe(*metricsAddr, mux); err != nil {
+				logger.Error("metrics server failed", "error", err)
+			}
+		}()
+	}
+
+	cleanupCtx, stopCleanup := context.WithCancel(context.Background())
+	defer stopCleanup()
+	srv.StartExpiryCleanup(cleanupCtx, *cleanupInterval)
+	srv.StartPurgeReaper(cleanupCtx, *purgeInterval, *purgeRetention)
+
+	// On SIGINT/SIGTERM drain in-flight requests, then force a stop if
+	// the timeout elapses; Serve returns once the server has stopped.
+	sigCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	go func() {
+		<-sigCtx.Done()
+		srv.Shutdown(grpcServer, *shutdownTimeout)
+	}()
+
+	logger.Info("gophkeeper server listening", "address", *addr)
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("serve: %v", err)
+	}
+}
+
+// newLogger builds the process logger from the -log-level and -log-json
+// flags. Everything logs to stderr, like the stdlib default.
+func newLogger(level string, asJSON bool) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("unknown log level %q", level)
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	if asJSON {
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts)), nil
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, opts)), nil
+}
+
+// tokenConfig picks the signing scheme: RS256 when a key file is given,
+// HS256 with the shared secret otherwise.
+func tokenConfig(secret, privateKeyFile, publicKeyFile string, duration time.Duration) (*auth.TokenConfig, error) {
+	var cfg *auth.TokenConfig
+	if privateKeyFile != "" || publicKeyFile != "" {
+		var privPEM, pubPEM []byte
+		var err error
+		if privateKeyFile != "" {
+			if privPEM, err = os.ReadFile(privateKeyFile); err != nil {
+				return nil, err
+			}
+		}
+		if publicKeyFile != "" {
+			if pubPEM, err = os.ReadFile(publicKeyFile); err != nil {
+				return nil, err
+			}
+		}
+		if cfg, err = auth.NewRS256Config(privPEM, pubPEM); err != nil {
+			return nil, err
+		}
+	} else {
+		cfg = auth.NewHS256Config([]byte(secret))
+	}
+	cfg.Duration = duration
+	return cfg, nil
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The agent keeps the master password in memory and hands it to other
+// client processes over a unix socket, so scripted runs of add/get/list
+// do not re-prompt. It exits on its own once the TTL elapses.
+
+// DefaultAgentTTL is how long an agent keeps serving the master
+// password when no idle timeout is given.
+const DefaultAgentTTL = 15 * time.Minute
+
+// agentIODeadline bounds each read or write on the agent socket so a
+// stuck peer cannot wedge either side.
+const agentIODeadline = time.Second
+
+// AgentSocketPath returns the standard location of the agent socket,
+// ~/.gophkeeper/agent.sock.
+func AgentSocketPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gophkeeper", "agent.sock"), nil
+}
+
+// ServeAgent listens on the unix socket at path and writes password to
+// every connection asking for it. The agent exits once it has been idle
+// for ttl — each request restarts the clock — or when a client sends
+// quit (see StopAgent). A stale socket left by a dead agent is
+// replaced.
+func ServeAgent(path, password string, ttl time.Duration) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create agent directory: %w", err)
+	}
+	os.Remove(path)
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listen on agent socket: %w", err)
+	}
+	defer os.Remove(path)
+	defer l.Close()
+	if err := os.Chmod(path, 0o600); err != nil {
+		return fmt.Errorf("restrict agent socket: %w", err)
+	}
+
+	timer := time.AfterFunc(ttl, func() { l.Close() })
+	defer timer.Stop()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil // idle timeout elapsed or quit received
+			}
+			return fmt.Errorf("accept agent connection: %w", err)
+		}
+		conn.SetDeadline(time.Now().Add(agentIODeadline))
+		cmd, err := bufio.NewReader(conn).ReadString('\n')
+		switch {
+		case err == nil && strings.TrimSuffix(cmd, "\n") == agentCmdGet:
+			timer.Reset(ttl)
+			conn.Write([]byte(password + "\n"))
+		case err == nil && strings.TrimSuffix(cmd, "\n") == agentCmdQuit:
+			conn.Close()
+			return nil
+		}
+		conn.Close()
+	}
+}
+
+// Commands understood by the agent, one line per connection.
+const (
+	agentCmdGet  = "get"
+	agentCmdQuit = "quit"
+)
+
+// AskAgent fetches the master password from an agent listening at
+// path. It fails when no agent is running there.
+func AskAgent(path string) (string, error) {
+	conn, err := net.DialTimeout("unix", path, agentIODeadline)
+	if err != nil {
+		return "", fmt.Errorf("connect to agent: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(agentIODeadline))
+	if _, err := conn.Write([]byte(agentCmdGet + "\n")); err != nil {
+		return "", fmt.Errorf("write to agent: %w", err)
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("read from agent: %w", err)
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+// StopAgent tells the agent listening at path to forget the master
+// password and exit. It fails when no agent is running there.
+func StopAgent(path string) error {
+	conn, err := net.DialTimeout("unix", path, agentIODeadline)
+	if err != nil {
+		return fmt.Errorf("connect to agent: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(agentIODeadline))
+	if _, err := conn.Write([]byte(agentCmdQuit + "\n")); err != nil {
+		return fmt.Errorf("write to agent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAgentServesPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	done := make(chan error, 1)
+	go func() { done <- ServeAgent(path, "master-pw", 2*time.Second) }()
+
+	// Give the listener a moment to come up.
+	var got string
+	var err error
+	for i := 0; i < 50; i++ {
+		if got, err = AskAgent(path); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("ask agent: %v", err)
+	}
+	if got != "master-pw" {
+		t.Fatalf("got %q, want %q", got, "master-pw")
+	}
+
+	// A second client is served by the same agent.
+	if got, err = AskAgent(path); err != nil || got != "master-pw" {
+		t.Fatalf("second ask: got %q, %v", got, err)
+	}
+}
+
+func TestAgentExitsAfterTTL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	done := make(chan error, 1)
+	go func() { done <- ServeAgent(path, "master-pw", 100*time.Millisecond) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("agent exited with error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("agent did not exit after its TTL")
+	}
+	if _, err := AskAgent(path); err == nil {
+		t.Fatal("agent answered after its TTL elapsed")
+	}
+}
+
+func TestAgentIdleTimeoutExtendsOnUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	done := make(chan error, 1)
+	go func() { done <- ServeAgent(path, "master-pw", 400*time.Millisecond) }()
+	var err error
+	for i := 0; i < 50; i++ {
+		if _, err = AskAgent(path); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("ask agent: %v", err)
+	}
+
+	// Keep asking past the original deadline; each request must push
+	// it out.
+	deadline := time.Now().Add(1200 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if _, err := AskAgent(path); err != nil {
+			t.Fatalf("agent expired while in use: %v", err)
+		}
+		time.Sleep(150 * time.Millisecond)
+	}
+	if err := StopAgent(path); err != nil {
+		t.Fatalf("stop agent: %v", err)
+	}
+}
+
+func TestStopAgent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	done := make(chan error, 1)
+	go func() { done <- ServeAgent(path, "master-pw", time.Minute) }()
+
+	var err error
+	for i := 0; i < 50; i++ {
+		if _, err = AskAgent(path); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("ask agent: %v", err)
+	}
+	if err := StopAgent(path); err != nil {
+		t.Fatalf("stop agent: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("agent exited with error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("agent kept running after lock")
+	}
+	if _, err := AskAgent(path); err == nil {
+		t.Fatal("agent answered after it was stopped")
+	}
+}
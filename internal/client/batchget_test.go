@@ -0,0 +1,53 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetItemsDecryptsBatch(t *testing.T) {
+	c := newConnectedClient(t)
+	for _, name := range []string{"alpha", "beta"} {
+		if _, err := c.AddItem(name, "text", []byte("secret-"+name), nil, nil, time.Time{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := c.GetItems([]string{"alpha", "beta"})
+	if err != nil {
+		t.Fatalf("get items: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for i, name := range []string{"alpha", "beta"} {
+		r := results[i]
+		if r.Name != name || r.Err != nil {
+			t.Fatalf("bad result for %q: %+v", name, r)
+		}
+		if string(r.Data) != "secret-"+name {
+			t.Fatalf("wrong plaintext for %q: %q", name, r.Data)
+		}
+	}
+}
+
+func TestGetItemsMixedFoundAndMissing(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("present", "text", []byte("here"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := c.GetItems([]string{"present", "absent"})
+	if err != nil {
+		t.Fatalf("get items: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Err != nil || string(results[0].Data) != "here" {
+		t.Fatalf("present item not served: %+v", results[0])
+	}
+	if results[1].Err == nil || results[1].Item != nil {
+		t.Fatalf("absent item not reported: %+v", results[1])
+	}
+}
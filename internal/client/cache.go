@@ -0,0 +1,159 @@
+package client
+
+// This file implements the local item cache backing offline reads.
+// Payloads are stored exactly as the server holds them: still encrypted
+// under the master password, so the cache file leaks no more than the
+// server database would.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// cachePath is the cache file next to the config file.
+func (c *Client) cachePath() string {
+	return filepath.Join(filepath.Dir(c.cfgPath), "cache.json")
+}
+
+// loadCache reads the cached items. A missing file is an empty cache.
+func (c *Client) loadCache() ([]*models.Item, error) {
+	data, err := os.ReadFile(c.cachePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cache: %w", err)
+	}
+	var items []*models.Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("parse cache: %w", err)
+	}
+	return items, nil
+}
+
+// storeCache writes the cache with owner-only permissions.
+func (c *Client) storeCache(items []*models.Item) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("encode cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.cachePath()), 0o700); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.cachePath(), data, 0o600); err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	return nil
+}
+
+// cacheItems merges items into the cache by name, replacing older
+// copies. Caching is best-effort: reads must not fail because the
+// cache could not be written, so callers ignore the error or log it.
+func (c *Client) cacheItems(items ...*models.Item) error {
+	cached, err := c.loadCache()
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]int, len(cached))
+	for i, item := range cached {
+		byName[item.Name] = i
+	}
+	for _, item := range items {
+		if i, ok := byName[item.Name]; ok {
+			cached[i] = item
+		} else {
+			byName[item.Name] = len(cached)
+			cached = append(cached, item)
+		}
+	}
+	return c.storeCache(cached)
+}
+
+// removeCachedItems drops the named items from the cache, typically
+// because sync reported them deleted on another device.
+func (c *Client) removeCachedItems(names ...string) error {
+	cached, err := c.loadCache()
+	if err != nil {
+		return err
+	}
+	drop := make(map[string]bool, len(names))
+	for _, name := range names {
+		drop[name] = true
+	}
+	kept := cached[:0]
+	for _, item := range cached {
+		if !drop[item.Name] {
+			kept = append(kept, item)
+		}
+	}
+	return c.storeCache(kept)
+}
+
+// cachedItem fetches one item from the cache by name.
+func (c *Client) cachedItem(name string) (*models.Item, bool) {
+	cached, err := c.loadCache()
+	if err != nil {
+		return nil, false
+	}
+	for _, item := range cached {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// cachedList pages through cached items the same way the server pages
+// through stored ones: filtered by metadata and tags, in stable order.
+func (c *Client) cachedList(limit, offset int64, filter map[string]string, tags []string) ([]*models.Item, int64, error) {
+	cached, err := c.loadCache()
+	if err != nil {
+		return nil, 0, err
+	}
+	var matched []*models.Item
+	for _, item := range cached {
+		if metadataMatches(item.Metadata, filter) && tagsMatch(item.Tags, tags) {
+			matched = append(matched, item)
+		}
+	}
+	total := int64(len(matched))
+	if offset > total {
+		offset = total
+	}
+	matched = matched[offset:]
+	if limit > 0 && int64(len(matched)) > limit {
+		matched = matched[:limit]
+	}
+	return matched, total, nil
+}
+
+// metadataMatches reports whether meta includes every pair in filter.
+func metadataMatches(meta, filter map[string]string) bool {
+	for k, v := range filter {
+		if meta[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// tagsMatch reports whether have includes every tag in want.
+func tagsMatch(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"time"
+
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/ar11/gophkeeper/internal/server"
+	"github.com/ar11/gophkeeper/internal/storage"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestOfflineReadsFromCache(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	srv := server.New(store, []byte("test-secret"))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(srv.AuthInterceptor()))
+	pb.RegisterGophKeeperServer(grpcServer, srv)
+	go grpcServer.Serve(listener)
+
+	c := newTestClient(t)
+	if err := c.Connect(listener.Addr().String(), "", true); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := c.Register("offline-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddItem("mail", "login_password", []byte("secret"), map[string]string{"env": "work"}, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddItem("wifi", "text", []byte("hunter2"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sync populates the local cache.
+	if _, err := c.Sync(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Kill the server; reads must fall back to the cache.
+	grpcServer.Stop()
+
+	item, plaintext, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatalf("get with server down: %v", err)
+	}
+	if string(plaintext) != "secret" || item.Name != "mail" {
+		t.Fatalf("cache returned wrong item: %q / %q", item.Name, plaintext)
+	}
+
+	items, total, err := c.ListItems(0, 0, nil, nil, "", "")
+	if err != nil {
+		t.Fatalf("list with server down: %v", err)
+	}
+	if total != 2 || len(items) != 2 {
+		t.Fatalf("cached list returned %d items (total %d), want 2", len(items), total)
+	}
+
+	items, total, err = c.ListItems(0, 0, map[string]string{"env": "work"}, nil, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || items[0].Name != "mail" {
+		t.Fatalf("cached filter returned %v (total %d), want just mail", items, total)
+	}
+}
+
+func TestForcedOfflineMode(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("mail", "text", []byte("secret"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Sync(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetOffline(true)
+
+	if _, plaintext, err := c.GetItem("mail"); err != nil || string(plaintext) != "secret" {
+		t.Fatalf("offline get: %q, %v", plaintext, err)
+	}
+	if _, _, err := c.ListItems(0, 0, nil, nil, "", ""); err != nil {
+		t.Fatalf("offline list: %v", err)
+	}
+
+	// Offline writes are queued locally and readable right away.
+	if _, err := c.AddItem("new", "text", []byte("x"), nil, nil, time.Time{}); err != nil {
+		t.Fatalf("offline add: %v", err)
+	}
+	if _, plaintext, err := c.GetItem("new"); err != nil || string(plaintext) != "x" {
+		t.Fatalf("offline-added item not readable: %q, %v", plaintext, err)
+	}
+
+	// Deletes still need the server and must fail fast, not hang.
+	if err := c.DeleteItem("mail"); err == nil {
+		t.Fatal("offline delete succeeded")
+	}
+}
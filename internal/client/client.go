@@ -0,0 +1,158 @@
+package client
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// verifierPlaintext is the known constant encrypted into Config.Verifier.
+// Successfully decrypting it back proves the master password is correct
+// without touching any real item.
+var verifierPlaintext = []byte("gophkeeper master password verifier v1")
+
+// ErrWrongMasterPassword is returned when the supplied master password
+// does not match the stored verifier.
+var ErrWrongMasterPassword = errors.New("wrong master password")
+
+// Client is the stateful CLI client. It caches the master password as a
+// byte slice (never a string) so it can be wiped from memory with
+// ClearMasterPassword when no longer needed.
+type Client struct {
+	cfg        *Config
+	cfgPath    string
+	masterPass []byte
+
+	// masterKey is the Argon2id-derived session key, computed once per
+	// session and then expanded into cheap per-item subkeys.
+	masterKey []byte
+
+	// store gives access to the user's items. It is wired up once the
+	// client connects to a server.
+	store ItemStore
+
+	// creds, when set, keeps the auth tokens out of the config file.
+	// See SetCredentialStore.
+	creds CredentialStore
+
+	offline bool
+	conn    *grpc.ClientConn
+	rpc     pb.GophKeeperClient
+
+	// maxMsgSize overrides the gRPC message size limit for the dial;
+	// zero keeps the 4MB default.
+	maxMsgSize int
+
+	// retryMax and retryBase shape the transient-failure retry policy;
+	// zero values fall back to the package defaults.
+	retryMax  int
+	retryBase time.Duration
+
+	// callTimeout overrides the per-call RPC timeout for this run; zero
+	// falls back to the config file, then defaultRPCTimeout.
+	callTimeout time.Duration
+
+	// compress enables gzip compression of item data before encryption.
+	// Incompressible payloads are stored unchanged either way.
+	compress bool
+}
+
+// New loads the config at cfgPath (which may not exist yet) and returns
+// a client ready for use.
+func New(cfgPath string) (*Client, error) {
+	cfg, err := LoadConfig(cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{cfg: cfg, cfgPath: cfgPath}, nil
+}
+
+// VerifyMasterPassword checks pass against the stored verifier blob and
+// returns ErrWrongMasterPassword on mismatch. On first use, when no
+// verifier exists yet, it creates one from pass and persists it, so the
+// first password supplied becomes the reference. A successful check
+// caches the password for subsequent encrypt/decrypt operations.
+func (c *Client) VerifyMasterPassword(pass string) error {
+	if len(c.cfg.Verifier) == 0 {
+		blob, err := crypto.EncryptWithPassword(pass, verifierPlaintext)
+		if err != nil {
+			return fmt.Errorf("create verifier: %w", err)
+		}
+		c.cfg.Verifier = blob
+		if err := c.saveConfig(); err != nil {
+			return fmt.Errorf("save verifier: %w", err)
+		}
+		c.setMasterPassword(pass)
+		return nil
+	}
+
+	got, err := crypto.DecryptWithPassword(pass, c.cfg.Verifier)
+	if err != nil || !crypto.ConstantTimeEqual(got, verifierPlaintext) {
+		return ErrWrongMasterPassword
+	}
+	c.setMasterPassword(pass)
+	return nil
+}
+
+// ClearMasterPassword wipes the cached master password from memory.
+// See crypto.Zeroize for the limitations of wiping memory in Go.
+func (c *Client) ClearMasterPassword() {
+	crypto.Zeroize(c.masterPass)
+	c.masterPass = nil
+	crypto.Zeroize(c.masterKey)
+	c.masterKey = nil
+}
+
+// ItemKey returns the per-item encryption key for itemID, deriving and
+// caching the session master key on first use. VerifyMasterPassword
+// must have succeeded beforehand.
+func (c *Client) ItemKey(itemID string) ([]byte, error) {
+	if len(c.masterPass) == 0 {
+		return nil, errors.New("master password not set")
+	}
+	if c.masterKey == nil {
+		if len(c.cfg.KeySalt) == 0 {
+			salt := make([]byte, crypto.SaltSize)
+			if _, err := rand.Read(salt); err != nil {
+				return nil, fmt.Errorf("generate key salt: %w", err)
+			}
+			c.cfg.KeySalt = salt
+			if err := c.saveConfig(); err != nil {
+				return nil, fmt.Errorf("save key salt: %w", err)
+			}
+		}
+		c.masterKey = crypto.DeriveMasterKey(c.masterPassword(), c.cfg.KeySalt)
+	}
+	return crypto.DeriveItemKey(c.masterKey, itemID)
+}
+
+// SetCompression toggles gzip compression of item data before it is
+// encrypted. Existing items are unaffected until rewritten.
+func (c *Client) SetCompression(on bool) {
+	c.compress = on
+}
+
+// encryptItemData seals plaintext for the named item, honouring the
+// client's compression setting.
+func (c *Client) encryptItemData(name string, plaintext []byte) ([]byte, error) {
+	if c.compress {
+		return crypto.EncryptWithPasswordAADCompressed(c.masterPassword(), plaintext, []byte(name), crypto.SuiteAESGCM)
+	}
+	return crypto.EncryptWithPasswordAAD(c.masterPassword(), plaintext, []byte(name), crypto.SuiteAESGCM)
+}
+
+func (c *Client) setMasterPassword(pass string) {
+	c.ClearMasterPassword()
+	c.masterPass = []byte(pass)
+}
+
+// masterPassword returns the cached master password for crypto calls.
+func (c *Client) masterPassword() string {
+	return string(c.masterPass)
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	c, err := New(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	return c
+}
+
+func TestVerifyMasterPasswordFirstUse(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatalf("first use should initialize verifier: %v", err)
+	}
+	if len(c.cfg.Verifier) == 0 {
+		t.Fatal("verifier was not persisted in config")
+	}
+}
+
+func TestVerifyMasterPassword(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatalf("initialize verifier: %v", err)
+	}
+
+	// Reload from disk to prove the verifier survives restarts.
+	reloaded, err := New(c.cfgPath)
+	if err != nil {
+		t.Fatalf("reload client: %v", err)
+	}
+	if err := reloaded.VerifyMasterPassword("master"); err != nil {
+		t.Fatalf("correct password rejected: %v", err)
+	}
+	if err := reloaded.VerifyMasterPassword("not-master"); !errors.Is(err, ErrWrongMasterPassword) {
+		t.Fatalf("expected ErrWrongMasterPassword, got %v", err)
+	}
+}
+
+func TestClearMasterPassword(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+	c.ClearMasterPassword()
+	if c.masterPassword() != "" {
+		t.Fatal("master password not cleared")
+	}
+}
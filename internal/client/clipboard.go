@@ -0,0 +1,61 @@
+package client
+
+// This file wraps the system clipboard behind a small interface so the
+// timed-clear logic can be tested without a display server.
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atotto/clipboard"
+)
+
+// DefaultClipboardTimeout is how long a copied secret stays on the
+// clipboard before it is cleared.
+const DefaultClipboardTimeout = 15 * time.Second
+
+// ErrNoClipboard is returned on headless systems with no clipboard.
+var ErrNoClipboard = errors.New("no system clipboard available on this machine")
+
+// Clipboard is the minimal clipboard surface CopySecret needs.
+type Clipboard interface {
+	Write(text string) error
+	Read() (string, error)
+}
+
+// SystemClipboard returns the real clipboard, or ErrNoClipboard when
+// the platform has none (typically a headless server).
+func SystemClipboard() (Clipboard, error) {
+	if clipboard.Unsupported {
+		return nil, ErrNoClipboard
+	}
+	return systemClipboard{}, nil
+}
+
+type systemClipboard struct{}
+
+func (systemClipboard) Write(text string) error { return clipboard.WriteAll(text) }
+func (systemClipboard) Read() (string, error)   { return clipboard.ReadAll() }
+
+// CopySecret puts secret on cb, waits for timeout, then clears the
+// clipboard — unless the user has copied something else in the
+// meantime, which is left alone. It blocks until the clear happens so
+// a CLI process stays alive long enough to do it.
+func CopySecret(cb Clipboard, secret string, timeout time.Duration) error {
+	if err := cb.Write(secret); err != nil {
+		return fmt.Errorf("copy to clipboard: %w", err)
+	}
+	if timeout <= 0 {
+		timeout = DefaultClipboardTimeout
+	}
+	time.Sleep(timeout)
+	current, err := cb.Read()
+	if err != nil || current != secret {
+		return nil
+	}
+	if err := cb.Write(""); err != nil {
+		return fmt.Errorf("clear clipboard: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClipboard is an in-memory Clipboard for tests.
+type fakeClipboard struct {
+	content string
+}
+
+func (f *fakeClipboard) Write(text string) error { f.content = text; return nil }
+func (f *fakeClipboard) Read() (string, error)   { return f.content, nil }
+
+func TestCopySecretClearsAfterTimeout(t *testing.T) {
+	cb := &fakeClipboard{}
+	if err := CopySecret(cb, "hunter2", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if cb.content != "" {
+		t.Fatalf("clipboard still holds %q after the timeout", cb.content)
+	}
+}
+
+func TestCopySecretLeavesNewerContent(t *testing.T) {
+	cb := &fakeClipboard{}
+	done := make(chan error, 1)
+	go func() { done <- CopySecret(cb, "hunter2", 50*time.Millisecond) }()
+	time.Sleep(10 * time.Millisecond)
+	cb.Write("something the user copied")
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if cb.content != "something the user copied" {
+		t.Fatalf("clear stomped on newer clipboard content: %q", cb.content)
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// mockConditionalClient serves one stored item and honours if_version
+// the way the real server does, recording what each fetch asked for.
+type mockConditionalClient struct {
+	pb.GophKeeperClient
+
+	stored     *pb.Item
+	ifVersions []int64
+}
+
+func (m *mockConditionalClient) AddItem(_ context.Context, req *pb.AddItemRequest, _ ...grpc.CallOption) (*pb.AddItemResponse, error) {
+	m.stored = req.GetItem()
+	m.stored.Id = 7
+	m.stored.Version = 1
+	return &pb.AddItemResponse{Id: 7}, nil
+}
+
+func (m *mockConditionalClient) GetItem(_ context.Context, req *pb.GetItemRequest, _ ...grpc.CallOption) (*pb.GetItemResponse, error) {
+	m.ifVersions = append(m.ifVersions, req.GetIfVersion())
+	if req.GetIfVersion() >= m.stored.GetVersion() {
+		return &pb.GetItemResponse{NotModified: true}, nil
+	}
+	return &pb.GetItemResponse{Item: m.stored}, nil
+}
+
+func TestGetItemUsesConditionalFetch(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockConditionalClient{}
+	c.WithGRPCClient(mock)
+
+	secret := []byte("user:hunter2")
+	if _, err := c.AddItem("mail", models.TypeLoginPassword, secret, nil, nil, time.Time{}); err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+
+	// First read: nothing cached yet, so the fetch is unconditional and
+	// the payload comes over the wire.
+	item, plaintext, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatalf("first get: %v", err)
+	}
+	if !bytes.Equal(plaintext, secret) {
+		t.Fatalf("first read returned %q", plaintext)
+	}
+	if len(mock.ifVersions) != 1 || mock.ifVersions[0] != 0 {
+		t.Fatalf("first fetch sent if_version %v, want [0]", mock.ifVersions)
+	}
+	if item.Version != 1 {
+		t.Fatalf("item version = %d, want 1", item.Version)
+	}
+
+	// Second read: the cached version rides along and the server's
+	// not_modified answer is served from the cache.
+	_, plaintext, err = c.GetItem("mail")
+	if err != nil {
+		t.Fatalf("second get: %v", err)
+	}
+	if !bytes.Equal(plaintext, secret) {
+		t.Fatalf("cached read returned %q", plaintext)
+	}
+	if len(mock.ifVersions) != 2 || mock.ifVersions[1] != 1 {
+		t.Fatalf("second fetch sent if_version %v, want [0 1]", mock.ifVersions)
+	}
+}
@@ -0,0 +1,103 @@
+// Package client implements the GophKeeper command-line client: local
+// configuration, master-password handling and, eventually, the gRPC
+// calls against the server.
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Config is the client's persistent local state, stored as JSON in the
+// user's config directory. Secrets never appear here in plaintext.
+type Config struct {
+	// Token is the auth token from the last successful login.
+	Token string `json:"token,omitempty"`
+
+	// RefreshToken is exchanged for a new token pair when Token expires.
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// Verifier is an encrypted check value used to validate the master
+	// password before any item operation. See VerifyMasterPassword.
+	Verifier []byte `json:"verifier,omitempty"`
+
+	// ServerAddress is the address of the last server connected to,
+	// used when no -s flag is given.
+	ServerAddress string `json:"server_address,omitempty"`
+
+	// Username is the last login name used, so login can default it.
+	Username string `json:"username,omitempty"`
+
+	// RPCTimeout bounds each unary call, as a Go duration string like
+	// "30s". Empty means the built-in default; sync and streamed
+	// transfers get a multiple of this budget.
+	RPCTimeout string `json:"rpc_timeout,omitempty"`
+
+	// LastSync records when the last successful Sync finished.
+	LastSync time.Time `json:"last_sync,omitempty"`
+
+	// KeySalt is the persistent salt for deriving the session master
+	// key. It is generated once and must not change, or per-item keys
+	// would no longer match existing data.
+	KeySalt []byte `json:"key_salt,omitempty"`
+
+	// Profiles holds named server profiles; CurrentProfile names the
+	// active one. The top-level connection fields above always mirror
+	// the active profile, so configs from before profiles existed keep
+	// working as a single unnamed profile. See profile.go.
+	Profiles       map[string]*ServerProfile `json:"profiles,omitempty"`
+	CurrentProfile string                    `json:"current_profile,omitempty"`
+}
+
+// DefaultConfigPath returns the standard location of the client config
+// file, ~/.gophkeeper/config.json.
+func DefaultConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gophkeeper", "config.json"), nil
+}
+
+// LoadConfig reads the config file at path. A missing file is not an
+// error: it returns an empty config so first use works without setup.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	// The active profile's connection state becomes the live state the
+	// rest of the client reads.
+	if p, ok := cfg.Profiles[cfg.CurrentProfile]; ok {
+		cfg.ServerAddress, cfg.Token, cfg.RefreshToken, cfg.Username =
+			p.ServerAddress, p.Token, p.RefreshToken, p.Username
+	}
+	return &cfg, nil
+}
+
+// Save writes the config to path, creating the parent directory if
+// needed. The file is written with owner-only permissions.
+func (c *Config) Save(path string) error {
+	c.stashActiveProfile()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
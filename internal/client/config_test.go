@@ -0,0 +1,73 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := &Config{
+		Token:         "tok",
+		ServerAddress: "vault.example.com:3200",
+		Username:      "alice",
+	}
+	if err := cfg.Save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("config file mode = %o, want 600", perm)
+	}
+
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if loaded.Token != cfg.Token || loaded.ServerAddress != cfg.ServerAddress || loaded.Username != cfg.Username {
+		t.Fatalf("round trip lost fields: %+v", loaded)
+	}
+}
+
+func TestServerAddressPrecedence(t *testing.T) {
+	c := newTestClient(t)
+	if got := c.ServerAddress("", "fallback:1"); got != "fallback:1" {
+		t.Fatalf("empty config: got %q, want the fallback", got)
+	}
+	c.cfg.ServerAddress = "saved:2"
+	if got := c.ServerAddress("", "fallback:1"); got != "saved:2" {
+		t.Fatalf("saved address ignored: got %q", got)
+	}
+	// An explicit flag always wins over the saved address.
+	if got := c.ServerAddress("flag:3", "fallback:1"); got != "flag:3" {
+		t.Fatalf("flag did not override config: got %q", got)
+	}
+}
+
+func TestConnectAndLoginRememberDefaults(t *testing.T) {
+	addr, _ := startTestServer(t)
+	c := newTestClient(t)
+	if err := c.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Register("memo-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := LoadConfig(c.cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.ServerAddress != addr {
+		t.Fatalf("server address not saved: %q", reloaded.ServerAddress)
+	}
+	if reloaded.Username != "memo-user" {
+		t.Fatalf("username not saved: %q", reloaded.Username)
+	}
+}
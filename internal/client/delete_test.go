@@ -0,0 +1,33 @@
+package client
+
+import (
+	"time"
+
+	"strings"
+	"testing"
+)
+
+func TestDeleteItemByName(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("doomed", "text", []byte("secret"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteItem("doomed"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, _, err := c.GetItem("doomed"); err == nil {
+		t.Fatal("item still fetchable after delete")
+	}
+}
+
+func TestDeleteMissingItem(t *testing.T) {
+	c := newConnectedClient(t)
+	err := c.DeleteItem("never-existed")
+	if err == nil {
+		t.Fatal("deleting a missing item succeeded")
+	}
+	if !strings.Contains(err.Error(), "no item named") {
+		t.Fatalf("got %q, want a friendly no-item-named error", err)
+	}
+}
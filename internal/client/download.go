@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// DownloadItem streams the named item's encrypted payload from the
+// server, decrypts it and writes the plaintext to path. Unlike GetItem
+// it is not bound by the unary message size limit, so it works for
+// large binaries. progress, when non-nil, is called after every
+// received chunk with the encrypted bytes received so far and the
+// total. The stream context has no deadline: download time scales with
+// the payload.
+func (c *Client) DownloadItem(name, path string, progress func(received, total int64)) error {
+	if err := c.requireOnline(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.rpc.DownloadItem(ctx, &pb.DownloadItemRequest{Name: name})
+	if err != nil {
+		return fmt.Errorf("download item: %w", err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return fmt.Errorf("item %q has expired", name)
+		}
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("no item named %q", name)
+		}
+		return fmt.Errorf("download item: %w", err)
+	}
+	total := first.GetTotalSize()
+
+	data := make([]byte, 0, total)
+	data = append(data, first.GetData()...)
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("download item: %w", err)
+		}
+		data = append(data, chunk.GetData()...)
+		if progress != nil {
+			progress(int64(len(data)), total)
+		}
+	}
+	if int64(len(data)) != total {
+		return fmt.Errorf("download item: got %d of %d bytes", len(data), total)
+	}
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if crypto.IsStreamFormat(data) {
+		// Stream-encrypted payloads decrypt frame by frame.
+		if err := crypto.DecryptStream(c.masterPassword(), bytes.NewReader(data), out); err != nil {
+			return fmt.Errorf("decrypt item %q: %w", name, err)
+		}
+	} else {
+		plaintext, err := crypto.DecryptWithPasswordAAD(c.masterPassword(), data, []byte(name))
+		if err != nil {
+			return fmt.Errorf("decrypt item %q: %w", name, err)
+		}
+		if _, err := out.Write(plaintext); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+func TestDownloadItemLargerThanMessageLimit(t *testing.T) {
+	c, store, userID := newSyncClient(t)
+
+	// Seed a payload past the 4MB default gRPC message size directly on
+	// the server; a unary GetItem could not return it.
+	plaintext := make([]byte, 5<<20)
+	if _, err := rand.Read(plaintext); err != nil {
+		t.Fatal(err)
+	}
+	data, err := crypto.EncryptWithPasswordAAD("master", plaintext, []byte("big-file"), crypto.SuiteAESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddItem(context.Background(), &models.Item{UserID: userID, Name: "big-file", Type: models.TypeBinary, Data: data}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "big-file.bin")
+	var calls int
+	var lastReceived, lastTotal int64
+	err = c.DownloadItem("big-file", path, func(received, total int64) {
+		calls++
+		lastReceived, lastTotal = received, total
+	})
+	if err != nil {
+		t.Fatalf("download: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatal("downloaded file does not match the original plaintext")
+	}
+	if calls < 2 {
+		t.Fatalf("progress reported %d times, want multiple chunks", calls)
+	}
+	if lastReceived != lastTotal || lastTotal != int64(len(data)) {
+		t.Fatalf("final progress %d/%d, want %d/%d", lastReceived, lastTotal, len(data), len(data))
+	}
+}
+
+func TestDownloadMissingItem(t *testing.T) {
+	c, _, _ := newSyncClient(t)
+
+	err := c.DownloadItem("no-such-item", filepath.Join(t.TempDir(), "out"), nil)
+	if err == nil {
+		t.Fatal("downloading a missing item succeeded")
+	}
+}
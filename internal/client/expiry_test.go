@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+func TestGetExpiredItem(t *testing.T) {
+	c, store, userID := newSyncClient(t)
+
+	// Seed an already-expired item directly, as if it lapsed between
+	// being stored and being read.
+	if _, err := store.AddItem(context.Background(), &models.Item{
+		UserID: userID, Name: "stale", Type: "text", Data: []byte("x"),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := c.GetItem("stale")
+	if err == nil {
+		t.Fatal("expired item was returned")
+	}
+	if !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("got %q, want an expired error", err)
+	}
+
+	// Expired items are also invisible to list.
+	_, total, err := c.ListItems(0, 0, nil, nil, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 {
+		t.Fatalf("list saw %d items, want 0", total)
+	}
+}
+
+func TestAddItemWithFutureExpiry(t *testing.T) {
+	c, _, _ := newSyncClient(t)
+
+	if _, err := c.AddItem("session", "text", []byte("tok"), nil, nil, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	item, plaintext, err := c.GetItem("session")
+	if err != nil {
+		t.Fatalf("get before expiry: %v", err)
+	}
+	if string(plaintext) != "tok" || item.ExpiresAt.IsZero() {
+		t.Fatalf("item came back wrong: %q, expires %v", plaintext, item.ExpiresAt)
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+// This file implements portable vault backups. Payloads are written
+// exactly as the server stores them — encrypted under the master
+// password with the item name as associated data — so the backup file
+// is useless without that password, and import does not need to
+// re-encrypt anything.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+const (
+	exportFormat        = "gophkeeper-export"
+	exportFormatVersion = 1
+)
+
+// exportFile is the on-disk backup layout. The format marker and
+// version let a future importer reject files it does not understand.
+type exportFile struct {
+	Format  string         `json:"format"`
+	Version int            `json:"version"`
+	Items   []*models.Item `json:"items"`
+}
+
+// Export writes every item in the vault to path and returns how many
+// were written. The file is created with owner-only permissions.
+func (c *Client) Export(path string) (int, error) {
+	items, err := (&remoteStore{c: c}).ListItems()
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.MarshalIndent(exportFile{
+		Format:  exportFormat,
+		Version: exportFormatVersion,
+		Items:   items,
+	}, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("encode export: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return 0, fmt.Errorf("write export: %w", err)
+	}
+	return len(items), nil
+}
+
+// Import recreates the items from a backup written by Export. Items
+// whose name already exists in the vault are skipped unless overwrite
+// is set, in which case the stored copy is replaced. It returns how
+// many items were created or replaced and how many were skipped.
+func (c *Client) Import(path string, overwrite bool) (applied, skipped int, err error) {
+	if err := c.requireOnline(); err != nil {
+		return 0, 0, err
+	}
+	adds, updates, skipped, err := c.importSets(path, overwrite)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, replacement := range updates {
+		if err := c.importUpdate(replacement); err != nil {
+			return applied, skipped, err
+		}
+		applied++
+	}
+	// New items go in one atomic batch: a single round trip, and a
+	// failure leaves the vault untouched instead of half-imported.
+	if len(adds) > 0 {
+		if err := c.importAddBatch(adds); err != nil {
+			return applied, skipped, err
+		}
+		applied += len(adds)
+	}
+	return applied, skipped, nil
+}
+
+// ImportPlan describes what Import would do, without doing it.
+type ImportPlan struct {
+	Add    int // items that would be created
+	Update int // existing items that would be overwritten
+	Skip   int // existing items left alone without overwrite
+}
+
+// PreviewImport computes the plan for an Import with the same inputs
+// without changing the vault.
+func (c *Client) PreviewImport(path string, overwrite bool) (*ImportPlan, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	adds, updates, skipped, err := c.importSets(path, overwrite)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportPlan{Add: len(adds), Update: len(updates), Skip: skipped}, nil
+}
+
+// importSets reads the backup at path and splits its items into the
+// ones to create, the ones to overwrite (already rebased onto the
+// stored ID and version) and the count left alone.
+func (c *Client) importSets(path string, overwrite bool) (adds, updates []*models.Item, skipped int, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("read backup: %w", err)
+	}
+	var backup exportFile
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, nil, 0, fmt.Errorf("parse backup: %w", err)
+	}
+	if backup.Format != exportFormat {
+		return nil, nil, 0, fmt.Errorf("%s is not a gophkeeper backup", path)
+	}
+	if backup.Version != exportFormatVersion {
+		return nil, nil, 0, fmt.Errorf("unsupported backup version %d", backup.Version)
+	}
+
+	existing, err := (&remoteStore{c: c}).ListItems()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	byName := make(map[string]*models.Item, len(existing))
+	for _, item := range existing {
+		byName[item.Name] = item
+	}
+
+	for _, item := range backup.Items {
+		current, exists := byName[item.Name]
+		switch {
+		case exists && !overwrite:
+			skipped++
+		case exists:
+			// Replace the stored copy at its current version so the
+			// overwrite cannot race with an unseen change.
+			replacement := *item
+			replacement.ID = current.ID
+			replacement.Version = current.Version
+			updates = append(updates, &replacement)
+		default:
+			adds = append(adds, item)
+		}
+	}
+	return adds, updates, skipped, nil
+}
+
+// importAddBatch stores already-encrypted items in a single RPC.
+func (c *Client) importAddBatch(items []*models.Item) error {
+	req := &pb.BatchAddItemsRequest{}
+	for _, item := range items {
+		fresh := *item
+		fresh.ID = 0
+		fresh.Version = 0
+		req.Items = append(req.Items, itemToProto(&fresh))
+	}
+	ctx, cancel := c.slowContext()
+	defer cancel()
+	resp, err := c.rpc.BatchAddItems(ctx, req)
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+	if !resp.GetApplied() {
+		for _, result := range resp.GetResults() {
+			if result.GetError() != "" {
+				return fmt.Errorf("import %q: %s", result.GetName(), result.GetError())
+			}
+		}
+		return fmt.Errorf("import: batch rejected")
+	}
+	return nil
+}
+
+// importUpdate overwrites an existing item with already-encrypted data.
+func (c *Client) importUpdate(item *models.Item) error {
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	_, err := c.rpc.UpdateItem(ctx, &pb.UpdateItemRequest{Item: itemToProto(item)})
+	if err != nil {
+		return fmt.Errorf("import %q: %w", item.Name, err)
+	}
+	return nil
+}
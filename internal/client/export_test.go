@@ -0,0 +1,127 @@
+package client
+
+import (
+	"time"
+
+	"path/filepath"
+	"testing"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("mail", "login_password", []byte("secret"), map[string]string{"env": "work"}, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddItem("wifi", "text", []byte("hunter2"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	backup := filepath.Join(t.TempDir(), "vault.enc")
+	count, err := c.Export(backup)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("exported %d items, want 2", count)
+	}
+
+	// Wipe the vault, then restore it from the backup.
+	for _, name := range []string{"mail", "wifi"} {
+		if err := c.DeleteItem(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	applied, skipped, err := c.Import(backup, false)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if applied != 2 || skipped != 0 {
+		t.Fatalf("import applied %d, skipped %d; want 2, 0", applied, skipped)
+	}
+
+	item, plaintext, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "secret" || item.Metadata["env"] != "work" {
+		t.Fatalf("restored item wrong: %q %v", plaintext, item.Metadata)
+	}
+	if _, plaintext, err = c.GetItem("wifi"); err != nil || string(plaintext) != "hunter2" {
+		t.Fatalf("restored item wrong: %q, %v", plaintext, err)
+	}
+}
+
+func TestImportNameCollisions(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("mail", "text", []byte("original"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	backup := filepath.Join(t.TempDir(), "vault.enc")
+	if _, err := c.Export(backup); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateItem("mail", "", []byte("changed"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without -overwrite the existing item wins.
+	applied, skipped, err := c.Import(backup, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied != 0 || skipped != 1 {
+		t.Fatalf("import applied %d, skipped %d; want 0, 1", applied, skipped)
+	}
+	if _, plaintext, err := c.GetItem("mail"); err != nil || string(plaintext) != "changed" {
+		t.Fatalf("skip kept %q, %v; want the live value", plaintext, err)
+	}
+
+	// With -overwrite the backup copy replaces it.
+	applied, skipped, err = c.Import(backup, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied != 1 || skipped != 0 {
+		t.Fatalf("import applied %d, skipped %d; want 1, 0", applied, skipped)
+	}
+	if _, plaintext, err := c.GetItem("mail"); err != nil || string(plaintext) != "original" {
+		t.Fatalf("overwrite kept %q, %v; want the backup value", plaintext, err)
+	}
+}
+
+func TestPreviewImportReportsPlanWithoutApplying(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("kept", "text", []byte("live"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddItem("gone", "text", []byte("bye"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	backup := filepath.Join(t.TempDir(), "vault.enc")
+	if _, err := c.Export(backup); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteItem("gone"); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := c.PreviewImport(backup, false)
+	if err != nil {
+		t.Fatalf("preview import: %v", err)
+	}
+	if plan.Add != 1 || plan.Update != 0 || plan.Skip != 1 {
+		t.Fatalf("plan %+v, want add 1, update 0, skip 1", plan)
+	}
+	plan, err = c.PreviewImport(backup, true)
+	if err != nil {
+		t.Fatalf("preview import: %v", err)
+	}
+	if plan.Add != 1 || plan.Update != 1 || plan.Skip != 0 {
+		t.Fatalf("overwrite plan %+v, want add 1, update 1, skip 0", plan)
+	}
+
+	// Nothing was applied: the deleted item is still gone.
+	if _, _, err := c.GetItem("gone"); err == nil {
+		t.Fatal("dry run restored a deleted item")
+	}
+}
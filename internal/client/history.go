@@ -0,0 +1,79 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// ItemHistory returns the archived prior values of the named item,
+// newest first. The Data fields stay encrypted; use RestoreItem to
+// bring one back.
+func (c *Client) ItemHistory(name string) ([]*models.ItemVersion, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.ListItemVersions(ctx, &pb.ListItemVersionsRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("item history: %w", err)
+	}
+	var versions []*models.ItemVersion
+	for _, v := range resp.GetVersions() {
+		versions = append(versions, &models.ItemVersion{
+			Version:    v.GetVersion(),
+			Data:       v.GetData(),
+			Metadata:   v.GetMetadata(),
+			Tags:       v.GetTags(),
+			ArchivedAt: v.GetArchivedAt().AsTime(),
+		})
+	}
+	return versions, nil
+}
+
+// RestoreItem re-applies the archived value with the given version
+// number as a new update, so the current value is itself archived
+// before being replaced. The archived ciphertext is reused as-is; it
+// still decrypts because the item name (the associated data) is
+// unchanged.
+func (c *Client) RestoreItem(name string, version int64) error {
+	if err := c.requireOnline(); err != nil {
+		return err
+	}
+	versions, err := c.ItemHistory(name)
+	if err != nil {
+		return err
+	}
+	var archived *models.ItemVersion
+	for _, v := range versions {
+		if v.Version == version {
+			archived = v
+			break
+		}
+	}
+	if archived == nil {
+		return fmt.Errorf("item %q has no archived version %d", name, version)
+	}
+
+	current, err := c.fetchItem(name)
+	if err != nil {
+		return err
+	}
+	item := &models.Item{
+		ID:       current.ID,
+		Name:     current.Name,
+		Type:     current.Type,
+		Data:     archived.Data,
+		Metadata: archived.Metadata,
+		Tags:     archived.Tags,
+		Version:  current.Version,
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	if _, err := c.rpc.UpdateItem(ctx, &pb.UpdateItemRequest{Item: itemToProto(item)}); err != nil {
+		return fmt.Errorf("restore item: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestItemHistoryAndRestore(t *testing.T) {
+	c := newConnectedClient(t)
+
+	if _, err := c.AddItem("pin", "text", []byte("first"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateItem("pin", "", []byte("second"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateItem("pin", "", []byte("third"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := c.ItemHistory("pin")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("got %d archived versions, want 2", len(versions))
+	}
+	if versions[0].Version != 2 || versions[1].Version != 1 {
+		t.Fatalf("history not newest-first: %d, %d", versions[0].Version, versions[1].Version)
+	}
+
+	if err := c.RestoreItem("pin", 1); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	_, plaintext, err := c.GetItem("pin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "first" {
+		t.Fatalf("restored value = %q, want %q", plaintext, "first")
+	}
+
+	// The restore itself archived the value it replaced.
+	versions, err = c.ItemHistory("pin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("got %d archived versions after restore, want 3", len(versions))
+	}
+
+	if err := c.RestoreItem("pin", 99); err == nil {
+		t.Fatal("restoring a nonexistent version succeeded")
+	}
+}
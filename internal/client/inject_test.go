@@ -0,0 +1,75 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// mockGophKeeperClient is a hand-written pb.GophKeeperClient double. It
+// embeds the interface so only the methods a test exercises need
+// stubbing; calling anything else panics, which is the point.
+type mockGophKeeperClient struct {
+	pb.GophKeeperClient
+
+	addReq *pb.AddItemRequest
+	getReq *pb.GetItemRequest
+	stored *pb.Item
+}
+
+func (m *mockGophKeeperClient) AddItem(_ context.Context, req *pb.AddItemRequest, _ ...grpc.CallOption) (*pb.AddItemResponse, error) {
+	m.addReq = req
+	m.stored = req.GetItem()
+	return &pb.AddItemResponse{Id: 7}, nil
+}
+
+func (m *mockGophKeeperClient) GetItem(_ context.Context, req *pb.GetItemRequest, _ ...grpc.CallOption) (*pb.GetItemResponse, error) {
+	m.getReq = req
+	return &pb.GetItemResponse{Item: m.stored}, nil
+}
+
+func TestWithGRPCClientAddAndGet(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockGophKeeperClient{}
+	c.WithGRPCClient(mock)
+
+	secret := []byte("user:hunter2")
+	id, err := c.AddItem("mail", models.TypeLoginPassword, secret, map[string]string{"site": "example.com"}, nil, time.Time{})
+	if err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	if id != 7 {
+		t.Fatalf("id = %d, want the mock's 7", id)
+	}
+
+	sent := mock.addReq.GetItem()
+	if sent.GetName() != "mail" || sent.GetType() != models.TypeLoginPassword {
+		t.Fatalf("request carries %q/%q, want mail/%s", sent.GetName(), sent.GetType(), models.TypeLoginPassword)
+	}
+	if sent.GetMetadata()["site"] != "example.com" {
+		t.Fatalf("metadata not forwarded: %v", sent.GetMetadata())
+	}
+	if bytes.Contains(sent.GetData(), secret) {
+		t.Fatal("plaintext secret went over the wire")
+	}
+
+	item, plaintext, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatalf("get item: %v", err)
+	}
+	if mock.getReq.GetName() != "mail" {
+		t.Fatalf("get request names %q, want mail", mock.getReq.GetName())
+	}
+	if item.Name != "mail" || !bytes.Equal(plaintext, secret) {
+		t.Fatalf("round trip returned %q/%q", item.Name, plaintext)
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import "errors"
+
+// CredentialStore keeps the auth token pair somewhere safer than the
+// config file — typically the OS keychain (an implementation on top of
+// a library like github.com/zalando/go-keyring satisfies this
+// interface). When no store is configured the tokens stay in the
+// config file as before, which is also the fallback on platforms
+// without a keychain.
+type CredentialStore interface {
+	// SaveTokens stores the token pair, replacing any previous one.
+	SaveTokens(token, refreshToken string) error
+
+	// LoadTokens returns the stored token pair, or ErrNoStoredTokens
+	// when nothing has been saved yet.
+	LoadTokens() (token, refreshToken string, err error)
+
+	// DeleteTokens removes the stored pair. Deleting when nothing is
+	// stored is not an error.
+	DeleteTokens() error
+}
+
+// ErrNoStoredTokens is returned by CredentialStore.LoadTokens when the
+// store holds no token pair.
+var ErrNoStoredTokens = errors.New("no stored tokens")
+
+// SetCredentialStore routes token persistence through store. Tokens
+// already present in the config file are migrated into the store and
+// stripped from disk, so the file no longer holds them in plaintext.
+func (c *Client) SetCredentialStore(store CredentialStore) error {
+	c.creds = store
+	if c.cfg.Token != "" || c.cfg.RefreshToken != "" {
+		if err := store.SaveTokens(c.cfg.Token, c.cfg.RefreshToken); err != nil {
+			return err
+		}
+		return c.saveConfig()
+	}
+	token, refresh, err := store.LoadTokens()
+	if errors.Is(err, ErrNoStoredTokens) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.cfg.Token = token
+	c.cfg.RefreshToken = refresh
+	return nil
+}
+
+// saveConfig persists the config. With a credential store configured
+// the token fields are blanked for the write so they never reach the
+// file; in memory they stay available for outgoing RPCs.
+func (c *Client) saveConfig() error {
+	if c.creds == nil {
+		return c.cfg.Save(c.cfgPath)
+	}
+	token, refresh := c.cfg.Token, c.cfg.RefreshToken
+	c.cfg.Token, c.cfg.RefreshToken = "", ""
+	err := c.cfg.Save(c.cfgPath)
+	c.cfg.Token, c.cfg.RefreshToken = token, refresh
+	return err
+}
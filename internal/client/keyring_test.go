@@ -0,0 +1,109 @@
+package client
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// mockCredentialStore is an in-memory CredentialStore, standing in for
+// an OS keychain in tests.
+type mockCredentialStore struct {
+	token, refresh string
+	stored         bool
+}
+
+func (m *mockCredentialStore) SaveTokens(token, refreshToken string) error {
+	m.token, m.refresh, m.stored = token, refreshToken, true
+	return nil
+}
+
+func (m *mockCredentialStore) LoadTokens() (string, string, error) {
+	if !m.stored {
+		return "", "", ErrNoStoredTokens
+	}
+	return m.token, m.refresh, nil
+}
+
+func (m *mockCredentialStore) DeleteTokens() error {
+	m.token, m.refresh, m.stored = "", "", false
+	return nil
+}
+
+func TestCredentialStoreMigratesTokensOffDisk(t *testing.T) {
+	c := newTestClient(t)
+	c.cfg.Token = "tok"
+	c.cfg.RefreshToken = "refresh"
+	if err := c.cfg.Save(c.cfgPath); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &mockCredentialStore{}
+	if err := c.SetCredentialStore(store); err != nil {
+		t.Fatal(err)
+	}
+	if store.token != "tok" || store.refresh != "refresh" {
+		t.Fatalf("store holds %q/%q, want tok/refresh", store.token, store.refresh)
+	}
+	data, err := os.ReadFile(c.cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "tok") {
+		t.Fatal("config file still contains the tokens after migration")
+	}
+	// The tokens remain usable in memory for outgoing RPCs.
+	if c.cfg.Token != "tok" || c.cfg.RefreshToken != "refresh" {
+		t.Fatal("in-memory tokens were lost during migration")
+	}
+}
+
+func TestCredentialStoreLoadsTokensOnStartup(t *testing.T) {
+	c := newTestClient(t)
+	store := &mockCredentialStore{token: "tok", refresh: "refresh", stored: true}
+	if err := c.SetCredentialStore(store); err != nil {
+		t.Fatal(err)
+	}
+	if c.cfg.Token != "tok" || c.cfg.RefreshToken != "refresh" {
+		t.Fatalf("got %q/%q, want tokens from the store", c.cfg.Token, c.cfg.RefreshToken)
+	}
+}
+
+func TestCredentialStoreDeleteOnClear(t *testing.T) {
+	c := newTestClient(t)
+	store := &mockCredentialStore{token: "tok", refresh: "refresh", stored: true}
+	if err := c.SetCredentialStore(store); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.clearTokens(); err != nil {
+		t.Fatal(err)
+	}
+	if store.stored {
+		t.Fatal("store still holds tokens after clear")
+	}
+	if c.cfg.Token != "" || c.cfg.RefreshToken != "" {
+		t.Fatal("in-memory tokens survived clear")
+	}
+}
+
+func TestSaveConfigKeepsTokensOutOfFile(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.SetCredentialStore(&mockCredentialStore{}); err != nil {
+		t.Fatal(err)
+	}
+	c.cfg.Token = "tok"
+	c.cfg.RefreshToken = "refresh"
+	if err := c.saveConfig(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(c.cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "tok") {
+		t.Fatal("tokens leaked into the config file")
+	}
+	if c.cfg.Token != "tok" || c.cfg.RefreshToken != "refresh" {
+		t.Fatal("in-memory tokens were lost by saveConfig")
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMaxMessageSize(t *testing.T) {
+	const limit = 256 * 1024
+	addr, _ := startConfiguredServer(t, nil,
+		grpc.MaxRecvMsgSize(limit), grpc.MaxSendMsgSize(limit))
+
+	c := newTestClient(t)
+	c.SetMaxMessageSize(limit)
+	if err := c.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Register("size-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Comfortably under the limit, leaving room for encryption and
+	// message overhead.
+	small := []byte(strings.Repeat("x", limit/2))
+	if _, err := c.AddItem("fits", "binary", small, nil, nil, time.Time{}); err != nil {
+		t.Fatalf("item under the limit failed: %v", err)
+	}
+
+	big := []byte(strings.Repeat("x", limit+1))
+	_, err := c.AddItem("too-big", "binary", big, nil, nil, time.Time{})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("item over the limit = %v, want ResourceExhausted", err)
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// itemEnvelope carries the secret value together with a free-text note
+// inside the encrypted payload, so the note is as protected as the
+// secret itself. Items without notes keep the legacy raw layout.
+type itemEnvelope struct {
+	Secret []byte `json:"secret"`
+	Notes  string `json:"notes"`
+}
+
+// envelopeMagic prefixes a JSON envelope so decryption can tell it
+// apart from a raw legacy payload. The NUL byte keeps it from
+// colliding with ordinary text secrets.
+var envelopeMagic = []byte("GKNOTE1\x00")
+
+// packPayload wraps secret and notes for encryption. Without notes the
+// secret is stored as-is, byte for byte.
+func packPayload(secret []byte, notes string) ([]byte, error) {
+	if notes == "" {
+		return secret, nil
+	}
+	b, err := json.Marshal(itemEnvelope{Secret: secret, Notes: notes})
+	if err != nil {
+		return nil, fmt.Errorf("encode payload: %w", err)
+	}
+	return append(append([]byte{}, envelopeMagic...), b...), nil
+}
+
+// unpackPayload reverses packPayload. Payloads without the envelope
+// marker are returned unchanged with empty notes.
+func unpackPayload(payload []byte) ([]byte, string) {
+	if !bytes.HasPrefix(payload, envelopeMagic) {
+		return payload, ""
+	}
+	var env itemEnvelope
+	if err := json.Unmarshal(bytes.TrimPrefix(payload, envelopeMagic), &env); err != nil {
+		return payload, ""
+	}
+	return env.Secret, env.Notes
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNotesRoundTrip(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItemWithNotes("mail", "login_password", []byte("hunter2"),
+		"recovery codes are in the safe", nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	item, plaintext, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "hunter2" {
+		t.Fatalf("secret is %q, want hunter2", plaintext)
+	}
+	if item.Notes != "recovery codes are in the safe" {
+		t.Fatalf("notes are %q", item.Notes)
+	}
+
+	// An ordinary edit keeps the note; an edit with notes replaces it.
+	if err := c.UpdateItem("mail", "", []byte("hunter3"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if item, _, err = c.GetItem("mail"); err != nil || item.Notes != "recovery codes are in the safe" {
+		t.Fatalf("plain edit lost the note: %q, %v", item.Notes, err)
+	}
+	if err := c.UpdateItemNotes("mail", "", []byte("hunter3"), "", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if item, _, err = c.GetItem("mail"); err != nil || item.Notes != "" {
+		t.Fatalf("note not removed: %q, %v", item.Notes, err)
+	}
+}
+
+func TestNotesNeverStoredInPlaintext(t *testing.T) {
+	c, store, userID := newSyncClient(t)
+	const note = "the-note-that-must-stay-secret"
+	if _, err := c.AddItemWithNotes("vaulted", "text", []byte("data"), note, nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := store.GetItemByName(context.Background(), userID, "vaulted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(stored.Data, []byte(note)) {
+		t.Fatal("note visible in the stored blob")
+	}
+	if stored.Notes != "" {
+		t.Fatal("note reached the server as a field")
+	}
+	for k, v := range stored.Metadata {
+		if v == note || k == note {
+			t.Fatal("note leaked into metadata")
+		}
+	}
+}
+
+func TestUnpackPayloadLegacyRaw(t *testing.T) {
+	secret, notes := unpackPayload([]byte("just a plain secret"))
+	if string(secret) != "just a plain secret" || notes != "" {
+		t.Fatalf("legacy payload mangled: %q %q", secret, notes)
+	}
+}
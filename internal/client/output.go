@@ -0,0 +1,84 @@
+package client
+
+// This file renders items as JSON for the CLI's -output json mode, so
+// scripts can parse get/list output instead of scraping the pretty
+// text.
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// redactedPlaceholder replaces secret payloads when -redact is set.
+const redactedPlaceholder = "[REDACTED]"
+
+// ItemJSON is the machine-readable form of one item. Data carries the
+// decrypted payload and is only set for single-item output; list
+// output leaves payloads encrypted and omits the field.
+type ItemJSON struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+	Version   int64             `json:"version"`
+	Data      string            `json:"data,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// MarshalItemJSON renders one item with its decrypted payload as JSON.
+// With redact set, card numbers are masked and every other payload is
+// replaced by a placeholder, so the output can go to logs or tickets.
+func MarshalItemJSON(item *models.Item, plaintext []byte, redact bool) ([]byte, error) {
+	out := itemJSON(item)
+	out.Data = string(plaintext)
+	if redact {
+		out.Data = redactedPlaceholder
+		if item.Type == models.TypeCard {
+			var card models.CardData
+			if err := json.Unmarshal(plaintext, &card); err == nil {
+				card.Number = card.Masked()
+				card.CVV = ""
+				masked, err := json.Marshal(card)
+				if err != nil {
+					return nil, fmt.Errorf("encode card data: %w", err)
+				}
+				out.Data = string(masked)
+			}
+		}
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// MarshalItemsJSON renders a list page as a JSON array. Payloads stay
+// encrypted and are not included.
+func MarshalItemsJSON(items []*models.Item) ([]byte, error) {
+	out := make([]*ItemJSON, 0, len(items))
+	for _, item := range items {
+		out = append(out, itemJSON(item))
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+func itemJSON(item *models.Item) *ItemJSON {
+	out := &ItemJSON{
+		ID:        item.ID,
+		Name:      item.Name,
+		Type:      item.Type,
+		Metadata:  item.Metadata,
+		Tags:      item.Tags,
+		Version:   item.Version,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+	if !item.ExpiresAt.IsZero() {
+		expires := item.ExpiresAt
+		out.ExpiresAt = &expires
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+func TestMarshalItemJSON(t *testing.T) {
+	item := &models.Item{
+		ID:        7,
+		Name:      "mail",
+		Type:      models.TypeLoginPassword,
+		Metadata:  map[string]string{"site": "example.com"},
+		Tags:      []string{"work"},
+		Version:   3,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	data, err := MarshalItemJSON(item, []byte("s3cret"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ItemJSON
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("output does not parse: %v", err)
+	}
+	if got.ID != 7 || got.Name != "mail" || got.Type != models.TypeLoginPassword {
+		t.Fatalf("got %+v", got)
+	}
+	if got.Data != "s3cret" || got.Version != 3 || got.Metadata["site"] != "example.com" {
+		t.Fatalf("got %+v", got)
+	}
+
+	redacted, err := MarshalItemJSON(item, []byte("s3cret"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(redacted), "s3cret") {
+		t.Fatalf("redacted output leaks the secret: %s", redacted)
+	}
+}
+
+func TestMarshalItemJSONRedactsCard(t *testing.T) {
+	payload, err := json.Marshal(models.CardData{
+		Number: "4111111111111111", ExpiryMonth: 12, ExpiryYear: 30, CVV: "123",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := &models.Item{ID: 1, Name: "card", Type: models.TypeCard}
+	data, err := MarshalItemJSON(item, payload, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	if strings.Contains(out, "4111111111111111") || strings.Contains(out, "123") {
+		t.Fatalf("redacted card output leaks secrets: %s", out)
+	}
+	if !strings.Contains(out, "1111") {
+		t.Fatalf("masked number missing last digits: %s", out)
+	}
+}
+
+func TestMarshalItemsJSON(t *testing.T) {
+	items := []*models.Item{
+		{ID: 1, Name: "a", Type: models.TypeText, Version: 1},
+		{ID: 2, Name: "b", Type: models.TypeCard, Version: 2},
+	}
+	data, err := MarshalItemsJSON(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []ItemJSON
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("output does not parse: %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "a" || got[1].Name != "b" {
+		t.Fatalf("got %+v", got)
+	}
+}
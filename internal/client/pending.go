@@ -0,0 +1,77 @@
+package client
+
+// This file tracks writes made while offline. Each queued item carries
+// the base version the edit started from (zero for brand-new items);
+// Sync pushes the queue and the server applies or reports conflicts.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// pendingPath is the offline write queue next to the config file.
+func (c *Client) pendingPath() string {
+	return filepath.Join(filepath.Dir(c.cfgPath), "pending.json")
+}
+
+// loadPending reads the queued offline writes.
+func (c *Client) loadPending() ([]*models.Item, error) {
+	data, err := os.ReadFile(c.pendingPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read pending changes: %w", err)
+	}
+	var items []*models.Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("parse pending changes: %w", err)
+	}
+	return items, nil
+}
+
+func (c *Client) storePending(items []*models.Item) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("encode pending changes: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.pendingPath()), 0o700); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	if err := os.WriteFile(c.pendingPath(), data, 0o600); err != nil {
+		return fmt.Errorf("write pending changes: %w", err)
+	}
+	return nil
+}
+
+// queuePending records an offline write, replacing an earlier queued
+// write to the same item so repeated edits collapse into one.
+func (c *Client) queuePending(item *models.Item) error {
+	pending, err := c.loadPending()
+	if err != nil {
+		return err
+	}
+	for i, queued := range pending {
+		if queued.Name == item.Name {
+			// Keep the original base version: the server must compare
+			// against what this device last saw, not the local edit.
+			item.Version = queued.Version
+			pending[i] = item
+			return c.storePending(pending)
+		}
+	}
+	return c.storePending(append(pending, item))
+}
+
+// clearPending drops the queue after a successful sync.
+func (c *Client) clearPending() error {
+	err := os.Remove(c.pendingPath())
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("clear pending changes: %w", err)
+	}
+	return nil
+}
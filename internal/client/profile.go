@@ -0,0 +1,83 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ServerProfile is one named server identity: where to connect and the
+// session belonging to it. Each profile keeps its own token pair, so
+// switching between a personal and a work server never mixes sessions.
+// The master password, verifier and key salt stay shared.
+type ServerProfile struct {
+	ServerAddress string `json:"server_address,omitempty"`
+	Token         string `json:"token,omitempty"`
+	RefreshToken  string `json:"refresh_token,omitempty"`
+	Username      string `json:"username,omitempty"`
+}
+
+// AddProfile registers a new named profile pointing at address and
+// persists the config. The new profile is not activated; see
+// UseProfile.
+func (c *Client) AddProfile(name, address string) error {
+	if name == "" {
+		return fmt.Errorf("profile name must not be blank")
+	}
+	if _, ok := c.cfg.Profiles[name]; ok {
+		return fmt.Errorf("a profile named %q already exists", name)
+	}
+	if c.cfg.Profiles == nil {
+		c.cfg.Profiles = make(map[string]*ServerProfile)
+	}
+	c.cfg.Profiles[name] = &ServerProfile{ServerAddress: address}
+	return c.saveConfig()
+}
+
+// UseProfile makes the named profile active: the live connection state
+// is stashed into the profile being switched away from, and the named
+// one's takes its place.
+func (c *Client) UseProfile(name string) error {
+	p, ok := c.cfg.Profiles[name]
+	if !ok {
+		return fmt.Errorf("no profile named %q", name)
+	}
+	c.cfg.stashActiveProfile()
+	c.cfg.CurrentProfile = name
+	c.cfg.ServerAddress, c.cfg.Token, c.cfg.RefreshToken, c.cfg.Username =
+		p.ServerAddress, p.Token, p.RefreshToken, p.Username
+	return c.saveConfig()
+}
+
+// Profiles returns the profile names in sorted order and the active
+// profile's name (empty when the config predates profiles).
+func (c *Client) Profiles() ([]string, string) {
+	names := make([]string, 0, len(c.cfg.Profiles))
+	for name := range c.cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, c.cfg.CurrentProfile
+}
+
+// ProfileInfo returns the named profile's address and username for
+// display. Tokens are deliberately not exposed.
+func (c *Client) ProfileInfo(name string) (address, username string, ok bool) {
+	c.cfg.stashActiveProfile()
+	p, found := c.cfg.Profiles[name]
+	if !found {
+		return "", "", false
+	}
+	return p.ServerAddress, p.Username, true
+}
+
+// stashActiveProfile writes the live connection state back into the
+// active profile's entry, so switching away or saving does not lose
+// tokens obtained since the last switch.
+func (c *Config) stashActiveProfile() {
+	p, ok := c.Profiles[c.CurrentProfile]
+	if !ok {
+		return
+	}
+	p.ServerAddress, p.Token, p.RefreshToken, p.Username =
+		c.ServerAddress, c.Token, c.RefreshToken, c.Username
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProfileSwitchingKeepsTokensSeparate(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.AddProfile("personal", "personal:3200"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddProfile("work", "work:3200"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UseProfile("personal"); err != nil {
+		t.Fatal(err)
+	}
+	c.cfg.Token, c.cfg.Username = "tok-personal", "alice"
+	if err := c.saveConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.UseProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	if c.cfg.Token != "" || c.cfg.Username != "" {
+		t.Fatalf("work profile inherited %q/%q from personal", c.cfg.Token, c.cfg.Username)
+	}
+	if c.cfg.ServerAddress != "work:3200" {
+		t.Fatalf("server address = %q, want work:3200", c.cfg.ServerAddress)
+	}
+	c.cfg.Token = "tok-work"
+
+	if err := c.UseProfile("personal"); err != nil {
+		t.Fatal(err)
+	}
+	if c.cfg.Token != "tok-personal" || c.cfg.ServerAddress != "personal:3200" {
+		t.Fatalf("personal profile lost its state: %q at %q", c.cfg.Token, c.cfg.ServerAddress)
+	}
+	if err := c.UseProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	if c.cfg.Token != "tok-work" {
+		t.Fatalf("work token = %q after switching back, want tok-work", c.cfg.Token)
+	}
+}
+
+func TestProfilePersistsAcrossLoads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	c, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddProfile("work", "work:3200"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UseProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	c.cfg.Token = "tok-work"
+	if err := c.saveConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, current := reloaded.Profiles()
+	if current != "work" || len(names) != 1 {
+		t.Fatalf("reloaded profiles = %v, current %q", names, current)
+	}
+	if reloaded.cfg.Token != "tok-work" || reloaded.cfg.ServerAddress != "work:3200" {
+		t.Fatalf("reloaded live state = %q at %q", reloaded.cfg.Token, reloaded.cfg.ServerAddress)
+	}
+}
+
+func TestProfileErrors(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.AddProfile("", "x:1"); err == nil {
+		t.Fatal("blank profile name accepted")
+	}
+	if err := c.AddProfile("work", "x:1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddProfile("work", "y:1"); err == nil {
+		t.Fatal("duplicate profile name accepted")
+	}
+	if err := c.UseProfile("nope"); err == nil {
+		t.Fatal("switching to an unknown profile succeeded")
+	}
+}
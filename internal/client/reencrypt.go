@@ -0,0 +1,78 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// ItemStore is the minimal item access the re-encryption flow needs.
+// The remote vault satisfies it; tests substitute an in-memory
+// implementation.
+type ItemStore interface {
+	ListItems() ([]*models.Item, error)
+	UpdateItem(item *models.Item) error
+}
+
+// ChangeMasterPassword re-encrypts every item from oldPass to newPass
+// and then replaces the stored verifier. The flow is idempotent: items
+// that already decrypt under newPass (from an earlier interrupted run)
+// are skipped, so rerunning after a partial failure finishes the job.
+// Items that fail to update are reported but do not stop the remaining
+// ones from being processed.
+func (c *Client) ChangeMasterPassword(oldPass, newPass string) error {
+	if c.store == nil {
+		return errors.New("not connected to a vault")
+	}
+	if err := c.VerifyMasterPassword(oldPass); err != nil {
+		return err
+	}
+
+	items, err := c.store.ListItems()
+	if err != nil {
+		return fmt.Errorf("list items: %w", err)
+	}
+
+	var errs []error
+	for _, item := range items {
+		plaintext, err := crypto.DecryptWithPasswordAAD(oldPass, item.Data, []byte(item.Name))
+		if err != nil {
+			// Already converted by a previous partial run?
+			if _, newErr := crypto.DecryptWithPasswordAAD(newPass, item.Data, []byte(item.Name)); newErr == nil {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("item %q: %w", item.Name, err))
+			continue
+		}
+
+		reencrypted, err := crypto.EncryptWithPasswordAAD(newPass, plaintext, []byte(item.Name), crypto.SuiteAESGCM)
+		crypto.Zeroize(plaintext)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("item %q: %w", item.Name, err))
+			continue
+		}
+
+		item.Data = reencrypted
+		if err := c.store.UpdateItem(item); err != nil {
+			errs = append(errs, fmt.Errorf("update item %q: %w", item.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("re-encryption incomplete, rerun to resume: %w", errors.Join(errs...))
+	}
+
+	// All items are on the new password; switch the verifier last so an
+	// interrupted run keeps validating against the old password.
+	verifier, err := crypto.EncryptWithPassword(newPass, verifierPlaintext)
+	if err != nil {
+		return fmt.Errorf("create verifier: %w", err)
+	}
+	c.cfg.Verifier = verifier
+	if err := c.saveConfig(); err != nil {
+		return fmt.Errorf("save verifier: %w", err)
+	}
+	c.setMasterPassword(newPass)
+	return nil
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// memStore is a minimal in-memory ItemStore for tests.
+type memStore struct {
+	items map[int64]*models.Item
+}
+
+func (m *memStore) ListItems() ([]*models.Item, error) {
+	out := make([]*models.Item, 0, len(m.items))
+	for _, item := range m.items {
+		copied := *item
+		out = append(out, &copied)
+	}
+	return out, nil
+}
+
+func (m *memStore) UpdateItem(item *models.Item) error {
+	stored, ok := m.items[item.ID]
+	if !ok {
+		return fmt.Errorf("item %d not found", item.ID)
+	}
+	stored.Data = item.Data
+	stored.Version++
+	return nil
+}
+
+func encryptedItem(t *testing.T, id int64, name, secret, pass string) *models.Item {
+	t.Helper()
+	data, err := crypto.EncryptWithPasswordAAD(pass, []byte(secret), []byte(name), crypto.SuiteAESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &models.Item{ID: id, Name: name, Type: models.TypeText, Data: data, Version: 1}
+}
+
+func TestChangeMasterPassword(t *testing.T) {
+	store := &memStore{items: map[int64]*models.Item{
+		1: encryptedItem(t, 1, "mail", "secret-1", "old-pass"),
+		2: encryptedItem(t, 2, "bank", "secret-2", "old-pass"),
+		// Simulate an interrupted earlier run: this item is already on
+		// the new password and must be skipped, not treated as corrupt.
+		3: encryptedItem(t, 3, "wifi", "secret-3", "new-pass"),
+	}}
+
+	c := newTestClient(t)
+	c.store = store
+	if err := c.VerifyMasterPassword("old-pass"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.ChangeMasterPassword("old-pass", "new-pass"); err != nil {
+		t.Fatalf("change master password: %v", err)
+	}
+
+	want := map[int64]string{1: "secret-1", 2: "secret-2", 3: "secret-3"}
+	for id, secret := range want {
+		item := store.items[id]
+		got, err := crypto.DecryptWithPasswordAAD("new-pass", item.Data, []byte(item.Name))
+		if err != nil {
+			t.Fatalf("item %d does not decrypt under new password: %v", id, err)
+		}
+		if string(got) != secret {
+			t.Fatalf("item %d plaintext = %q, want %q", id, got, secret)
+		}
+	}
+
+	// The verifier must now accept the new password only.
+	reloaded, err := New(c.cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.VerifyMasterPassword("new-pass"); err != nil {
+		t.Fatalf("new password rejected after change: %v", err)
+	}
+	if err := reloaded.VerifyMasterPassword("old-pass"); err == nil {
+		t.Fatal("old password still accepted after change")
+	}
+}
+
+func TestChangeMasterPasswordWrongOldPassword(t *testing.T) {
+	c := newTestClient(t)
+	c.store = &memStore{}
+	if err := c.VerifyMasterPassword("old-pass"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ChangeMasterPassword("bad-pass", "new-pass"); err == nil {
+		t.Fatal("expected wrong old password to fail")
+	}
+}
+
+func TestChangeMasterPasswordPath(t *testing.T) {
+	// Guard against accidentally writing outside the configured path.
+	c, err := New(filepath.Join(t.TempDir(), "nested", "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.store = &memStore{items: map[int64]*models.Item{}}
+	if err := c.VerifyMasterPassword("old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ChangeMasterPassword("old", "new"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newRefreshClient returns a registered client, so it holds a valid
+// refresh token, plus its refresh interceptor under test.
+func newRefreshClient(t *testing.T) (*Client, grpc.UnaryClientInterceptor) {
+	t.Helper()
+	addr, _ := startTestServer(t)
+	c := newTestClient(t)
+	if err := c.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Register("refresh-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+	return c, c.refreshInterceptor()
+}
+
+func TestRefreshInterceptorRetriesAfterUnauthenticated(t *testing.T) {
+	c, interceptor := newRefreshClient(t)
+	oldToken := c.cfg.Token
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls == 1 {
+			return status.Error(codes.Unauthenticated, "token expired")
+		}
+		return nil
+	}
+	err := interceptor(context.Background(), "/gophkeeper.GophKeeper/GetItem", nil, nil, c.conn, invoker)
+	if err != nil {
+		t.Fatalf("call not retried after refresh: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("invoker called %d times, want 2", calls)
+	}
+	if c.cfg.Token == oldToken {
+		t.Fatal("access token was not refreshed")
+	}
+}
+
+func TestRefreshInterceptorRetriesExactlyOnce(t *testing.T) {
+	c, interceptor := newRefreshClient(t)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unauthenticated, "token expired")
+	}
+	err := interceptor(context.Background(), "/gophkeeper.GophKeeper/GetItem", nil, nil, c.conn, invoker)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("got %v, want the Unauthenticated error back", err)
+	}
+	if calls != 2 {
+		t.Fatalf("invoker called %d times, want 2 (no retry loop)", calls)
+	}
+}
+
+func TestRefreshInterceptorSkipsLogin(t *testing.T) {
+	c, interceptor := newRefreshClient(t)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unauthenticated, "invalid login or password")
+	}
+	err := interceptor(context.Background(), "/gophkeeper.GophKeeper/Login", nil, nil, c.conn, invoker)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("got %v, want Unauthenticated", err)
+	}
+	if calls != 1 {
+		t.Fatalf("login was retried (%d calls); a wrong password cannot be refreshed away", calls)
+	}
+}
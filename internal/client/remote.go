@@ -0,0 +1,1082 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// SetMaxMessageSize overrides the gRPC message size limit used when
+// dialing; it must be called before Connect. Zero keeps gRPC's 4MB
+// default and must match the server's -max-msg-size setting.
+func (c *Client) SetMaxMessageSize(bytes int) {
+	c.maxMsgSize = bytes
+}
+
+// Connect dials the server at addr and wires up the remote vault. The
+// connection uses TLS with the system root pool by default; caCert adds
+// a custom CA (for self-signed server certs), and insecureTransport
+// disables TLS entirely for local development.
+func (c *Client) Connect(addr, caCert string, insecureTransport bool) error {
+	creds, err := transportCredentials(caCert, insecureTransport)
+	if err != nil {
+		return err
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(tokenCredentials{c}),
+		grpc.WithChainUnaryInterceptor(c.retryInterceptor(), c.refreshInterceptor()),
+	}
+	if c.maxMsgSize > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(c.maxMsgSize),
+			grpc.MaxCallSendMsgSize(c.maxMsgSize),
+		))
+	}
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	c.conn = conn
+	c.rpc = pb.NewGophKeeperClient(conn)
+	c.store = &remoteStore{c: c}
+	// Remember the address so the next invocation can omit -s.
+	// Best-effort: an unwritable config must not fail the connect.
+	if c.cfg.ServerAddress != addr {
+		c.cfg.ServerAddress = addr
+		c.saveConfig()
+	}
+	return nil
+}
+
+// WithGRPCClient wires an already-built gRPC client into c in place of
+// a dialed connection, so tests can drive the package against a
+// pb.GophKeeperClient double without a server. Production code keeps
+// using Connect; Close stays a no-op because nothing was dialed.
+func (c *Client) WithGRPCClient(rpc pb.GophKeeperClient) {
+	c.rpc = rpc
+	c.store = &remoteStore{c: c}
+}
+
+// ServerAddress picks the address to dial: an explicit flag value wins,
+// then the address saved by the last successful connect, then def.
+func (c *Client) ServerAddress(flagValue, def string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if c.cfg.ServerAddress != "" {
+		return c.cfg.ServerAddress
+	}
+	return def
+}
+
+// SavedUsername returns the login name from the last successful login.
+func (c *Client) SavedUsername() string {
+	return c.cfg.Username
+}
+
+// transportCredentials builds the transport credentials for a dial. The
+// server name is always verified against the certificate; there is no
+// skip-verify mode, only the explicit insecure one.
+func transportCredentials(caCert string, insecureTransport bool) (credentials.TransportCredentials, error) {
+	if insecureTransport {
+		return insecure.NewCredentials(), nil
+	}
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caCert)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// SetOffline forces cache-only mode: reads come from the local cache
+// and anything needing the server fails fast.
+func (c *Client) SetOffline(offline bool) {
+	c.offline = offline
+}
+
+// requireOnline rejects calls that cannot be served from the cache.
+func (c *Client) requireOnline() error {
+	if c.offline || c.rpc == nil {
+		return errors.New("this command needs a server connection")
+	}
+	return nil
+}
+
+// errUnreachable reports whether err means the server could not be
+// reached at all, as opposed to rejecting the request.
+func errUnreachable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// Close releases the server connection.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// Per-call time budgets. Sync and streamed transfers move item payloads
+// of arbitrary size, so they get a multiple of the base budget.
+const (
+	defaultRPCTimeout = 10 * time.Second
+	slowRPCFactor     = 6
+)
+
+// syncPageSize caps how many changed items one Sync response carries;
+// after a long offline period the rest is pulled page by page.
+const syncPageSize = 500
+
+// SetRPCTimeout overrides the per-call timeout for unary RPCs; Sync and
+// streamed transfers get slowRPCFactor times this budget. Zero falls
+// back to the value saved in the config, then the built-in default.
+func (c *Client) SetRPCTimeout(d time.Duration) {
+	c.callTimeout = d
+}
+
+// rpcTimeout resolves the unary-call budget: the explicit override
+// wins, then the config file, then the default.
+func (c *Client) rpcTimeout() time.Duration {
+	if c.callTimeout > 0 {
+		return c.callTimeout
+	}
+	if c.cfg.RPCTimeout != "" {
+		if d, err := time.ParseDuration(c.cfg.RPCTimeout); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultRPCTimeout
+}
+
+// rpcContext returns the context used for unary calls.
+func (c *Client) rpcContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.rpcTimeout())
+}
+
+// slowContext returns the context for sync and streamed transfers.
+func (c *Client) slowContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), slowRPCFactor*c.rpcTimeout())
+}
+
+// Register creates an account on the server and stores the returned
+// token in the local config.
+func (c *Client) Register(login, password string) error {
+	if strings.TrimSpace(login) == "" {
+		return fmt.Errorf("login must not be blank")
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.Register(ctx, &pb.RegisterRequest{Login: login, Password: password})
+	if err != nil {
+		return fmt.Errorf("register: %w", err)
+	}
+	c.cfg.Username = login
+	return c.saveTokens(resp)
+}
+
+// Login authenticates against the server and stores the returned token
+// in the local config.
+func (c *Client) Login(login, password string) error {
+	if strings.TrimSpace(login) == "" {
+		return fmt.Errorf("login must not be blank")
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.Login(ctx, &pb.LoginRequest{Login: login, Password: password})
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+	c.cfg.Username = login
+	return c.saveTokens(resp)
+}
+
+func (c *Client) saveTokens(resp *pb.AuthResponse) error {
+	c.cfg.Token = resp.GetToken()
+	c.cfg.RefreshToken = resp.GetRefreshToken()
+	if c.creds != nil {
+		if err := c.creds.SaveTokens(c.cfg.Token, c.cfg.RefreshToken); err != nil {
+			return fmt.Errorf("save tokens: %w", err)
+		}
+	}
+	if err := c.saveConfig(); err != nil {
+		return fmt.Errorf("save tokens: %w", err)
+	}
+	return nil
+}
+
+// clearTokens drops the token pair from memory, the config file and,
+// when configured, the credential store.
+func (c *Client) clearTokens() error {
+	c.cfg.Token = ""
+	c.cfg.RefreshToken = ""
+	if c.creds != nil {
+		if err := c.creds.DeleteTokens(); err != nil {
+			return fmt.Errorf("clear tokens: %w", err)
+		}
+	}
+	if err := c.saveConfig(); err != nil {
+		return fmt.Errorf("clear tokens: %w", err)
+	}
+	return nil
+}
+
+// Logout revokes the current session on the server and clears the
+// locally stored tokens.
+func (c *Client) Logout() error {
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	_, err := c.rpc.Logout(ctx, &pb.LogoutRequest{RefreshToken: c.cfg.RefreshToken})
+	if err != nil {
+		return fmt.Errorf("logout: %w", err)
+	}
+	return c.clearTokens()
+}
+
+// DeleteAccount permanently deletes the account on the server after
+// re-checking the account password, then wipes the local tokens and
+// cache. There is no undo.
+func (c *Client) DeleteAccount(password string) error {
+	if err := c.requireOnline(); err != nil {
+		return err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	if _, err := c.rpc.DeleteAccount(ctx, &pb.DeleteAccountRequest{Password: password}); err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return fmt.Errorf("invalid password")
+		}
+		return fmt.Errorf("delete account: %w", err)
+	}
+	// Local state is best-effort cleanup; the account is already gone.
+	os.Remove(c.cachePath())
+	c.cfg.LastSync = time.Time{}
+	return c.clearTokens()
+}
+
+// Profile identifies the account behind the active session.
+type Profile struct {
+	UserID         int64
+	Login          string
+	TokenExpiresAt time.Time
+	// LastLoginAt and LastLoginIP describe the most recent successful
+	// login; both are zero before the first one.
+	LastLoginAt time.Time
+	LastLoginIP string
+}
+
+// Whoami asks the server who the current token belongs to.
+func (c *Client) Whoami() (*Profile, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.GetProfile(ctx, &pb.GetProfileRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return nil, fmt.Errorf("session expired or not logged in; run login")
+		}
+		return nil, fmt.Errorf("whoami: %w", err)
+	}
+	profile := &Profile{
+		UserID:         resp.GetUserId(),
+		Login:          resp.GetLogin(),
+		TokenExpiresAt: resp.GetTokenExpiresAt().AsTime().Local(),
+		LastLoginIP:    resp.GetLastLoginIp(),
+	}
+	if resp.GetLastLoginAt() != nil {
+		profile.LastLoginAt = resp.GetLastLoginAt().AsTime().Local()
+	}
+	return profile, nil
+}
+
+// refreshMethod must never trigger a refresh itself.
+const refreshMethod = "/gophkeeper.GophKeeper/Refresh"
+
+// noRefreshMethods are the calls that do not ride on an access token.
+// Refreshing cannot help them, and retrying Login after a wrong
+// password would only repeat the failure.
+var noRefreshMethods = map[string]bool{
+	"/gophkeeper.GophKeeper/Register": true,
+	"/gophkeeper.GophKeeper/Login":    true,
+	refreshMethod:                     true,
+}
+
+// refreshInterceptor retries a call exactly once after transparently
+// exchanging the refresh token when the server rejects the access
+// token. The single retry is the loop cap: if the refreshed token is
+// rejected too, the error reaches the caller.
+func (c *Client) refreshInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || noRefreshMethods[method] || c.cfg.RefreshToken == "" {
+			return err
+		}
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+		resp, rerr := pb.NewGophKeeperClient(cc).Refresh(ctx, &pb.RefreshRequest{RefreshToken: c.cfg.RefreshToken})
+		if rerr != nil {
+			return err
+		}
+		if serr := c.saveTokens(resp); serr != nil {
+			return serr
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// tokenCredentials attaches the stored auth token to every RPC as an
+// "authorization" bearer header. It reads the token at call time so a
+// login on the same connection takes effect immediately. When no token
+// is stored yet (register, login) it sends nothing and lets the server
+// decide.
+type tokenCredentials struct {
+	c *Client
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	if t.c.cfg.Token == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + t.c.cfg.Token}, nil
+}
+
+// RequireTransportSecurity stays false so the -insecure development
+// path keeps working; with TLS enabled the header is protected anyway.
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// AddItem encrypts plaintext under the master password and stores it on
+// the server. The item name is bound into the ciphertext as associated
+// data. A non-zero expiresAt makes the item self-destruct at that time.
+func (c *Client) AddItem(name, itemType string, plaintext []byte, metadata map[string]string, tags []string, expiresAt time.Time) (int64, error) {
+	return c.AddItemWithNotes(name, itemType, plaintext, "", metadata, tags, expiresAt)
+}
+
+// AddItemWithNotes is AddItem plus a free-text note, stored inside the
+// encrypted payload alongside the secret.
+func (c *Client) AddItemWithNotes(name, itemType string, plaintext []byte, notes string, metadata map[string]string, tags []string, expiresAt time.Time) (int64, error) {
+	item := &models.Item{Name: name, Type: itemType, Metadata: metadata, Tags: tags, ExpiresAt: expiresAt}
+	if err := item.Validate(); err != nil {
+		return 0, err
+	}
+	payload, err := packPayload(plaintext, notes)
+	if err != nil {
+		return 0, err
+	}
+	data, err := c.encryptItemData(name, payload)
+	if err != nil {
+		return 0, fmt.Errorf("encrypt item: %w", err)
+	}
+	item.Data = data
+	if c.requireOnline() != nil {
+		// Offline: queue the write for the next sync and make it
+		// readable locally right away.
+		if err := c.queuePending(item); err != nil {
+			return 0, err
+		}
+		if err := c.cacheItems(item); err != nil {
+			return 0, err
+		}
+		return 0, nil
+	}
+
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	addReq := &pb.AddItemRequest{Item: itemToProto(item)}
+	// The key survives interceptor retries, so a network blip cannot
+	// create the item twice. Best-effort: without one the add still
+	// works, just without replay protection.
+	if key, err := newIdempotencyKey(); err == nil {
+		addReq.IdempotencyKey = key
+	}
+	resp, err := c.rpc.AddItem(ctx, addReq)
+	if status.Code(err) == codes.AlreadyExists {
+		dup := &DuplicateItemError{Name: name}
+		// Best-effort: the created date makes the warning actionable,
+		// but its absence must not mask the real error.
+		if existing, ferr := c.fetchItem(name); ferr == nil {
+			dup.CreatedAt = existing.CreatedAt
+		}
+		return 0, dup
+	}
+	if err != nil {
+		return 0, fmt.Errorf("add item: %w", err)
+	}
+	return resp.GetId(), nil
+}
+
+// newIdempotencyKey returns a random key identifying one logical
+// AddItem call across retries.
+func newIdempotencyKey() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// DuplicateItemError reports an add that collided with an existing
+// item of the same name. CreatedAt is when the stored item was
+// created, when that could be determined.
+type DuplicateItemError struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+func (e *DuplicateItemError) Error() string {
+	if e.CreatedAt.IsZero() {
+		return fmt.Sprintf("an item named %q already exists", e.Name)
+	}
+	return fmt.Sprintf("an item named %q already exists (created %s)",
+		e.Name, e.CreatedAt.Local().Format(time.DateOnly))
+}
+
+// GetItem fetches the named item and returns it along with the
+// decrypted payload. When the server is unreachable (or offline mode is
+// forced) the read is served from the local cache.
+func (c *Client) GetItem(name string) (*models.Item, []byte, error) {
+	item, err := c.fetchItem(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !item.ExpiresAt.IsZero() && !item.ExpiresAt.After(time.Now()) {
+		return nil, nil, fmt.Errorf("item %q has expired", name)
+	}
+	payload, err := crypto.DecryptWithPasswordAAD(c.masterPassword(), item.Data, []byte(item.Name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decrypt item %q: %w", item.Name, err)
+	}
+	plaintext, notes := unpackPayload(payload)
+	item.Notes = notes
+	return item, plaintext, nil
+}
+
+// BatchGetResult pairs one requested name with its decrypted item, or
+// with the error that kept it from being returned.
+type BatchGetResult struct {
+	Name string
+	Item *models.Item
+	Data []byte
+	Err  error
+}
+
+// GetItems fetches several items by name in a single round trip and
+// decrypts them locally. A name the server cannot serve, or an item
+// that fails to decrypt, gets a per-result error; the call itself only
+// fails when the batch as a whole does.
+func (c *Client) GetItems(names []string) ([]BatchGetResult, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.BatchGetItems(ctx, &pb.BatchGetItemsRequest{Names: names})
+	if err != nil {
+		return nil, fmt.Errorf("get items: %w", err)
+	}
+
+	results := make([]BatchGetResult, 0, len(resp.GetResults()))
+	for _, r := range resp.GetResults() {
+		result := BatchGetResult{Name: r.GetName()}
+		switch {
+		case r.GetError() != "":
+			result.Err = errors.New(r.GetError())
+		default:
+			item := itemFromProto(r.GetItem())
+			payload, err := crypto.DecryptWithPasswordAAD(c.masterPassword(), item.Data, []byte(item.Name))
+			if err != nil {
+				result.Err = fmt.Errorf("decrypt item %q: %w", item.Name, err)
+				break
+			}
+			c.cacheItems(item)
+			plaintext, notes := unpackPayload(payload)
+			item.Notes = notes
+			result.Item = item
+			result.Data = plaintext
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (c *Client) fetchItem(name string) (*models.Item, error) {
+	cached, haveCached := c.cachedItem(name)
+	if c.requireOnline() == nil {
+		ctx, cancel := c.rpcContext()
+		defer cancel()
+		req := &pb.GetItemRequest{Name: name}
+		if haveCached {
+			// Conditional fetch: skip the payload transfer when the
+			// cached copy is already the latest version.
+			req.IfVersion = cached.Version
+		}
+		resp, err := c.rpc.GetItem(ctx, req)
+		if err == nil {
+			if resp.GetNotModified() {
+				return cached, nil
+			}
+			item := itemFromProto(resp.GetItem())
+			// Best-effort: an unwritable cache must not fail the read.
+			c.cacheItems(item)
+			return item, nil
+		}
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, fmt.Errorf("item %q has expired", name)
+		}
+		if !errUnreachable(err) {
+			return nil, fmt.Errorf("get item: %w", err)
+		}
+	}
+	if !haveCached {
+		return nil, fmt.Errorf("no item named %q in the local cache: %w", name, ErrNotFound)
+	}
+	return cached, nil
+}
+
+// ListItems fetches one page of items, optionally filtered to those
+// whose metadata contains every pair in filter and whose tags include
+// every entry in tags. sortBy and order pick the ordering ("created",
+// "updated", "name" or "type", with "asc" or "desc"); empty values
+// keep the default creation order. Payloads are left encrypted; only
+// names, types and metadata are meant for display. When the server is
+// unreachable (or offline mode is forced) the page comes from the
+// local cache, which serves the default order only.
+func (c *Client) ListItems(limit, offset int64, filter map[string]string, tags []string, sortBy, order string) ([]*models.Item, int64, error) {
+	if c.requireOnline() == nil {
+		ctx, cancel := c.rpcContext()
+		defer cancel()
+		resp, err := c.rpc.ListItems(ctx, &pb.ListItemsRequest{Limit: limit, Offset: offset, MetadataFilter: filter, Tags: tags, SortBy: sortBy, Order: order})
+		if err == nil {
+			items := make([]*models.Item, 0, len(resp.GetItems()))
+			for _, it := range resp.GetItems() {
+				items = append(items, itemFromProto(it))
+			}
+			return items, resp.GetTotal(), nil
+		}
+		if !errUnreachable(err) {
+			return nil, 0, fmt.Errorf("list items: %w", err)
+		}
+	}
+	return c.cachedList(limit, offset, filter, tags)
+}
+
+// ChangePassword replaces the account password on the server. The
+// server ends every session, so the local tokens are cleared and the
+// next command must log in with the new password.
+func (c *Client) ChangePassword(current, newPassword string) error {
+	if err := c.requireOnline(); err != nil {
+		return err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	if _, err := c.rpc.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		CurrentPassword: current,
+		NewPassword:     newPassword,
+	}); err != nil {
+		return fmt.Errorf("change password: %w", err)
+	}
+	return c.clearTokens()
+}
+
+// TypeStat is one row of the vault summary returned by Stats.
+type TypeStat struct {
+	Type      string
+	Count     int64
+	TotalSize int64
+	Oldest    time.Time
+	Newest    time.Time
+}
+
+// Stats fetches the per-type vault summary, sorted by type name.
+func (c *Client) Stats() ([]TypeStat, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.Stats(ctx, &pb.StatsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("stats: %w", err)
+	}
+	stats := make([]TypeStat, 0, len(resp.GetTypes()))
+	for _, st := range resp.GetTypes() {
+		stats = append(stats, TypeStat{
+			Type:      st.GetType(),
+			Count:     st.GetCount(),
+			TotalSize: st.GetTotalSize(),
+			Oldest:    st.GetOldest().AsTime(),
+			Newest:    st.GetNewest().AsTime(),
+		})
+	}
+	return stats, nil
+}
+
+// SearchItems returns items whose name contains query.
+func (c *Client) SearchItems(query string) ([]*models.Item, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.SearchItems(ctx, &pb.SearchItemsRequest{Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("search items: %w", err)
+	}
+	items := make([]*models.Item, 0, len(resp.GetItems()))
+	for _, it := range resp.GetItems() {
+		items = append(items, itemFromProto(it))
+	}
+	return items, nil
+}
+
+// ErrNotFound marks failures caused by a missing item, so callers that
+// need to react to the class (the CLI's exit codes, scripts) can use
+// errors.Is instead of matching message text.
+var ErrNotFound = errors.New("not found")
+
+// ErrVersionConflict is returned when an edit raced with another change
+// to the same item; re-running the edit picks up the latest version.
+var ErrVersionConflict = errors.New("item was changed elsewhere, please retry the edit")
+
+// UpdateItem re-encrypts plaintext and overwrites the named item. The
+// current version is fetched first so a concurrent change on another
+// device is reported as ErrVersionConflict instead of silently lost. An
+// empty itemType, nil metadata or nil tags keeps the stored values.
+func (c *Client) UpdateItem(name, itemType string, plaintext []byte, metadata map[string]string, tags []string) error {
+	current, _, err := c.GetItem(name)
+	if err != nil {
+		return err
+	}
+	if itemType != "" {
+		current.Type = itemType
+	}
+	if metadata != nil {
+		current.Metadata = metadata
+	}
+	if tags != nil {
+		current.Tags = tags
+	}
+	// current.Notes came out of the decrypted payload, so an ordinary
+	// edit carries the note over unchanged.
+	return c.updateItem(current, plaintext)
+}
+
+// UpdateItemNotes is UpdateItem plus a replacement for the item's
+// note; an empty string removes it.
+func (c *Client) UpdateItemNotes(name, itemType string, plaintext []byte, notes string, metadata map[string]string, tags []string) error {
+	current, _, err := c.GetItem(name)
+	if err != nil {
+		return err
+	}
+	if itemType != "" {
+		current.Type = itemType
+	}
+	if metadata != nil {
+		current.Metadata = metadata
+	}
+	if tags != nil {
+		current.Tags = tags
+	}
+	current.Notes = notes
+	return c.updateItem(current, plaintext)
+}
+
+// updateItem encrypts plaintext and sends the update with the version
+// carried by item, mapping the server's conflict error.
+func (c *Client) updateItem(item *models.Item, plaintext []byte) error {
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	payload, err := packPayload(plaintext, item.Notes)
+	if err != nil {
+		return err
+	}
+	data, err := c.encryptItemData(item.Name, payload)
+	if err != nil {
+		return fmt.Errorf("encrypt item: %w", err)
+	}
+	item.Data = data
+	if c.requireOnline() != nil {
+		// Offline: queue the edit with its base version for the next
+		// sync and update the local cache.
+		if err := c.queuePending(item); err != nil {
+			return err
+		}
+		return c.cacheItems(item)
+	}
+
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	_, err = c.rpc.UpdateItem(ctx, &pb.UpdateItemRequest{Item: itemToProto(item)})
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return ErrVersionConflict
+		}
+		return fmt.Errorf("update item: %w", err)
+	}
+	return nil
+}
+
+// RotateItem replaces only the named item's secret value, leaving its
+// type, metadata and tags untouched. The stored version is bumped like
+// any other edit.
+func (c *Client) RotateItem(name string, newSecret []byte) error {
+	return c.UpdateItem(name, "", newSecret, nil, nil)
+}
+
+// DeleteItem removes the named item from the vault.
+func (c *Client) DeleteItem(name string) error {
+	if err := c.requireOnline(); err != nil {
+		return err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	_, err := c.rpc.DeleteItem(ctx, &pb.DeleteItemRequest{Name: name})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("no item named %q: %w", name, ErrNotFound)
+		}
+		return fmt.Errorf("delete item: %w", err)
+	}
+	return nil
+}
+
+// BatchDeleteResult pairs one requested name with the error that kept
+// it from being deleted.
+type BatchDeleteResult struct {
+	Name string
+	Err  error
+}
+
+// DeleteItems removes several items in one round trip. A name without
+// a live item gets a per-result error; the call itself only fails when
+// the batch as a whole does. Deleted names are dropped from the local
+// cache.
+func (c *Client) DeleteItems(names []string) ([]BatchDeleteResult, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.BatchDeleteItems(ctx, &pb.BatchDeleteItemsRequest{Names: names})
+	if err != nil {
+		return nil, fmt.Errorf("delete items: %w", err)
+	}
+	results := make([]BatchDeleteResult, 0, len(resp.GetResults()))
+	var deleted []string
+	for _, r := range resp.GetResults() {
+		result := BatchDeleteResult{Name: r.GetName()}
+		if r.GetError() != "" {
+			result.Err = errors.New(r.GetError())
+		} else {
+			deleted = append(deleted, r.GetName())
+		}
+		results = append(results, result)
+	}
+	if len(deleted) > 0 {
+		// Best-effort: an unwritable cache must not fail the delete.
+		c.removeCachedItems(deleted...)
+	}
+	return results, nil
+}
+
+// ListTrash returns the soft-deleted items still held on the server,
+// most recently deleted first. Payloads stay encrypted; names, types
+// and the deletion time are meant for display.
+func (c *Client) ListTrash() ([]*models.Item, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.ListTrash(ctx, &pb.ListTrashRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	items := make([]*models.Item, 0, len(resp.GetItems()))
+	for _, it := range resp.GetItems() {
+		items = append(items, itemFromProto(it))
+	}
+	return items, nil
+}
+
+// RestoreDeleted undoes a delete: the item with the given id comes
+// back out of the trash into normal reads, with a bumped version.
+func (c *Client) RestoreDeleted(id int64) (*models.Item, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	resp, err := c.rpc.RestoreItem(ctx, &pb.RestoreItemRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("no item with id %d in the trash: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("restore item: %w", err)
+	}
+	return itemFromProto(resp.GetItem()), nil
+}
+
+// PurgeItem permanently removes the named item, bypassing the server's
+// soft-delete retention. This also works on items already deleted with
+// DeleteItem that are still awaiting purge.
+func (c *Client) PurgeItem(name string) error {
+	if err := c.requireOnline(); err != nil {
+		return err
+	}
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	_, err := c.rpc.PurgeItem(ctx, &pb.PurgeItemRequest{Name: name})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("no item named %q: %w", name, ErrNotFound)
+		}
+		return fmt.Errorf("purge item: %w", err)
+	}
+	return nil
+}
+
+// SyncConflict pairs the local and server versions of an item changed
+// on both sides since the last sync.
+type SyncConflict struct {
+	Local  *models.Item
+	Server *models.Item
+}
+
+// SyncResult is the outcome of one Sync call.
+type SyncResult struct {
+	// Items changed on the server since the last sync.
+	Items []*models.Item
+	// Deleted names items removed on the server (or another device)
+	// since the last sync; they have been dropped from the local cache.
+	Deleted []string
+	// Conflicts that need user resolution; none of them were applied.
+	Conflicts []SyncConflict
+}
+
+// SyncPlan describes what Sync would do, without doing it.
+type SyncPlan struct {
+	Adds      int // local changes that would create a new item
+	Updates   int // local changes that would cleanly update an item
+	Conflicts int // local changes blocked by a concurrent server edit
+	Pulls     int // server-side changes that would be pulled
+}
+
+// PreviewSync reports the plan for a Sync with the same inputs. Nothing
+// is applied on either side: pending offline writes stay queued, and
+// the cache and last-sync marker are untouched.
+func (c *Client) PreviewSync(localChanges []*models.Item) (*SyncPlan, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	pending, err := c.loadPending()
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.SyncRequest{DryRun: true}
+	if !c.cfg.LastSync.IsZero() {
+		req.Since = timestamppb.New(c.cfg.LastSync)
+	}
+	for _, item := range append(pending, localChanges...) {
+		req.LocalChanges = append(req.LocalChanges, itemToProto(item))
+	}
+	ctx, cancel := c.slowContext()
+	defer cancel()
+	resp, err := c.rpc.Sync(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("sync preview: %w", err)
+	}
+	return &SyncPlan{
+		Adds:      int(resp.GetPlannedAdds()),
+		Updates:   int(resp.GetPlannedUpdates()),
+		Conflicts: len(resp.GetConflicts()),
+		Pulls:     len(resp.GetItems()),
+	}, nil
+}
+
+// Sync pushes pending offline writes (plus any extra localChanges the
+// caller supplies) to the server, then pulls items changed since the
+// last recorded sync time. Applied changes leave the queue, so running
+// it repeatedly is safe; conflicts are surfaced, never silently merged.
+func (c *Client) Sync(localChanges []*models.Item) (*SyncResult, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
+	pending, err := c.loadPending()
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.SyncRequest{PageSize: syncPageSize}
+	if !c.cfg.LastSync.IsZero() {
+		req.Since = timestamppb.New(c.cfg.LastSync)
+	}
+	for _, item := range append(pending, localChanges...) {
+		req.LocalChanges = append(req.LocalChanges, itemToProto(item))
+	}
+
+	started := time.Now()
+	var changed []*pb.Item
+	var conflicts []*pb.SyncConflict
+	for {
+		ctx, cancel := c.slowContext()
+		page, err := c.rpc.Sync(ctx, req)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("sync: %w", err)
+		}
+		changed = append(changed, page.GetItems()...)
+		conflicts = append(conflicts, page.GetConflicts()...)
+		if !page.GetMore() || len(page.GetItems()) == 0 {
+			break
+		}
+		// Local changes were applied on the first call; the rest of
+		// the pull continues from the last item of this page.
+		last := page.GetItems()[len(page.GetItems())-1]
+		req.LocalChanges = nil
+		req.CursorUpdatedAt = last.GetUpdatedAt()
+		req.CursorId = last.GetId()
+	}
+
+	result := &SyncResult{}
+	for _, it := range changed {
+		item := itemFromProto(it)
+		// A tombstone: the item was deleted on another device.
+		if !item.DeletedAt.IsZero() {
+			result.Deleted = append(result.Deleted, item.Name)
+			continue
+		}
+		result.Items = append(result.Items, item)
+	}
+	if len(result.Items) > 0 {
+		// Refresh the offline cache with everything that changed.
+		if err := c.cacheItems(result.Items...); err != nil {
+			return nil, fmt.Errorf("update local cache: %w", err)
+		}
+	}
+	if len(result.Deleted) > 0 {
+		if err := c.removeCachedItems(result.Deleted...); err != nil {
+			return nil, fmt.Errorf("update local cache: %w", err)
+		}
+	}
+	for _, conflict := range conflicts {
+		result.Conflicts = append(result.Conflicts, SyncConflict{
+			Local:  itemFromProto(conflict.GetLocal()),
+			Server: itemFromProto(conflict.GetServer()),
+		})
+	}
+
+	if err := c.clearPending(); err != nil {
+		return nil, err
+	}
+	c.cfg.LastSync = started
+	if err := c.saveConfig(); err != nil {
+		return nil, fmt.Errorf("save sync marker: %w", err)
+	}
+	return result, nil
+}
+
+// remoteStore adapts the RPC layer to the ItemStore interface used by
+// maintenance flows like ChangeMasterPassword.
+type remoteStore struct {
+	c *Client
+}
+
+// ListItems pages through the whole vault.
+func (r *remoteStore) ListItems() ([]*models.Item, error) {
+	var all []*models.Item
+	const pageSize = 100
+	for offset := int64(0); ; offset += pageSize {
+		items, total, err := r.c.ListItems(pageSize, offset, nil, nil, "", "")
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if int64(len(all)) >= total || len(items) == 0 {
+			return all, nil
+		}
+	}
+}
+
+func (r *remoteStore) UpdateItem(item *models.Item) error {
+	ctx, cancel := r.c.rpcContext()
+	defer cancel()
+	_, err := r.c.rpc.UpdateItem(ctx, &pb.UpdateItemRequest{
+		Item: itemToProto(item),
+	})
+	if err != nil {
+		return fmt.Errorf("update item: %w", err)
+	}
+	return nil
+}
+
+func itemFromProto(item *pb.Item) *models.Item {
+	out := &models.Item{
+		ID:        item.GetId(),
+		Name:      item.GetName(),
+		Type:      item.GetType(),
+		Data:      item.GetData(),
+		Metadata:  item.GetMetadata(),
+		Tags:      item.GetTags(),
+		Version:   item.GetVersion(),
+		CreatedAt: item.GetCreatedAt().AsTime(),
+		UpdatedAt: item.GetUpdatedAt().AsTime(),
+	}
+	if item.GetExpiresAt() != nil {
+		out.ExpiresAt = item.GetExpiresAt().AsTime()
+	}
+	if item.GetDeletedAt() != nil {
+		out.DeletedAt = item.GetDeletedAt().AsTime()
+	}
+	return out
+}
+
+func itemToProto(item *models.Item) *pb.Item {
+	out := &pb.Item{
+		Id:       item.ID,
+		Name:     item.Name,
+		Type:     item.Type,
+		Data:     item.Data,
+		Metadata: item.Metadata,
+		Tags:     item.Tags,
+		Version:  item.Version,
+	}
+	if !item.ExpiresAt.IsZero() {
+		out.ExpiresAt = timestamppb.New(item.ExpiresAt)
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Retry policy defaults: three extra attempts starting at 200ms roughly
+// doubles a command's worst-case wait, which is acceptable for an
+// interactive CLI riding over flaky links.
+const (
+	defaultRetryMax  = 3
+	defaultRetryBase = 200 * time.Millisecond
+)
+
+// SetRetryPolicy overrides how transient RPC failures are retried:
+// maxRetries extra attempts with exponential backoff starting at base.
+// A negative maxRetries disables retries entirely. It must be called
+// before Connect.
+func (c *Client) SetRetryPolicy(maxRetries int, base time.Duration) {
+	c.retryMax = maxRetries
+	c.retryBase = base
+}
+
+// nonRetryableMethods are mutations the server may have applied even
+// though the reply was lost; blindly resending them could duplicate the
+// write (Register would report the account as taken) or burn the
+// one-shot refresh token. AddItem is retryable: the idempotency key it
+// carries makes the server answer a resend with the original result.
+// Updates and deletes are safe too: the version check and the
+// soft-delete guard make a resend a no-op or a clean conflict.
+var nonRetryableMethods = map[string]bool{
+	"/gophkeeper.GophKeeper/Register": true,
+	refreshMethod:                     true,
+}
+
+// retryInterceptor retries calls that failed because the server was
+// unreachable, with exponential backoff and jitter. It sits outside the
+// refresh interceptor in the chain, so each attempt still gets the
+// token-refresh treatment.
+func (c *Client) retryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		maxRetries := c.retryMax
+		if maxRetries == 0 {
+			maxRetries = defaultRetryMax
+		}
+		base := c.retryBase
+		if base <= 0 {
+			base = defaultRetryBase
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || nonRetryableMethods[method] {
+			return err
+		}
+		delay := base
+		for attempt := 0; attempt < maxRetries && errUnreachable(err); attempt++ {
+			// Full jitter keeps simultaneous clients from retrying in
+			// lockstep against a server that just came back.
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay + time.Duration(rand.Int63n(int64(delay)))):
+			}
+			delay *= 2
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return err
+	}
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func newRetryInterceptor(t *testing.T, maxRetries int) grpc.UnaryClientInterceptor {
+	t.Helper()
+	c := newTestClient(t)
+	c.SetRetryPolicy(maxRetries, time.Millisecond)
+	return c.retryInterceptor()
+}
+
+func TestRetryRecoversFromTransientFailures(t *testing.T) {
+	interceptor := newRetryInterceptor(t, 3)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls <= 2 {
+			return status.Error(codes.Unavailable, "connection refused")
+		}
+		return nil
+	}
+	err := interceptor(context.Background(), "/gophkeeper.GophKeeper/GetItem", nil, nil, nil, invoker)
+	if err != nil {
+		t.Fatalf("call not retried to success: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("invoker called %d times, want 3", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	interceptor := newRetryInterceptor(t, 2)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+	err := interceptor(context.Background(), "/gophkeeper.GophKeeper/GetItem", nil, nil, nil, invoker)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("got %v, want the Unavailable error back", err)
+	}
+	if calls != 3 {
+		t.Fatalf("invoker called %d times, want 3 (initial + 2 retries)", calls)
+	}
+}
+
+func TestRetrySkipsUnsafeMutations(t *testing.T) {
+	interceptor := newRetryInterceptor(t, 3)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "connection reset")
+	}
+	err := interceptor(context.Background(), "/gophkeeper.GophKeeper/Register", nil, nil, nil, invoker)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("got %v, want Unavailable", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Register was retried (%d calls); it may already have been applied", calls)
+	}
+}
+
+func TestRetryReplaysAddItemSafely(t *testing.T) {
+	interceptor := newRetryInterceptor(t, 3)
+
+	// A fake server applying the same idempotency rule as the real one:
+	// a key already seen answers with the stored id, nothing new is
+	// created.
+	created := map[string]int64{}
+	calls := 0
+	invoker := func(_ context.Context, _ string, req, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		calls++
+		key := req.(*pb.AddItemRequest).GetIdempotencyKey()
+		if _, ok := created[key]; !ok {
+			created[key] = int64(len(created) + 1)
+		}
+		if calls == 1 {
+			// The write was applied but the reply got lost in a blip.
+			return status.Error(codes.Unavailable, "connection reset")
+		}
+		reply.(*pb.AddItemResponse).Id = created[key]
+		return nil
+	}
+
+	req := &pb.AddItemRequest{
+		Item:           &pb.Item{Name: "retried"},
+		IdempotencyKey: "blip-1",
+	}
+	resp := &pb.AddItemResponse{}
+	err := interceptor(context.Background(), "/gophkeeper.GophKeeper/AddItem", req, resp, nil, invoker)
+	if err != nil {
+		t.Fatalf("blipped AddItem not retried to success: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("invoker called %d times, want 2", calls)
+	}
+	if len(created) != 1 {
+		t.Fatalf("retry created %d items, want 1", len(created))
+	}
+	if resp.GetId() != 1 {
+		t.Fatalf("retry returned id %d, want the original 1", resp.GetId())
+	}
+}
+
+func TestRetryLeavesPermanentErrorsAlone(t *testing.T) {
+	interceptor := newRetryInterceptor(t, 3)
+
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.NotFound, "no such item")
+	}
+	err := interceptor(context.Background(), "/gophkeeper.GophKeeper/GetItem", nil, nil, nil, invoker)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("got %v, want NotFound", err)
+	}
+	if calls != 1 {
+		t.Fatalf("a permanent error was retried (%d calls)", calls)
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+// This file validates SSH key material before it is encrypted and
+// stored, so a mangled paste is caught at add time rather than when the
+// key is needed.
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// NewSSHKeyData bundles an SSH key pair for storage. The private key
+// must be valid PEM (OpenSSH, PKCS#8 or the legacy formats); for an
+// encrypted key the passphrase must decrypt it.
+func NewSSHKeyData(name, privatePEM, publicKey, passphrase, comment string) (*models.SSHKeyData, error) {
+	if err := validateSSHPrivateKey(privatePEM, passphrase); err != nil {
+		return nil, err
+	}
+	return &models.SSHKeyData{
+		Name:       name,
+		PrivateKey: privatePEM,
+		PublicKey:  publicKey,
+		Passphrase: passphrase,
+		Comment:    comment,
+	}, nil
+}
+
+func validateSSHPrivateKey(privatePEM, passphrase string) error {
+	_, err := ssh.ParseRawPrivateKey([]byte(privatePEM))
+	if err == nil {
+		return nil
+	}
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == "" {
+			return fmt.Errorf("private key is encrypted, a passphrase is required")
+		}
+		if _, err := ssh.ParseRawPrivateKeyWithPassphrase([]byte(privatePEM), []byte(passphrase)); err != nil {
+			return fmt.Errorf("decrypt private key: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("parse private key: %w", err)
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// testPrivateKeyPEM generates an ed25519 private key in PKCS#8 PEM form.
+func testPrivateKeyPEM(t *testing.T) string {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestSSHKeyDataRoundTrip(t *testing.T) {
+	pemKey := testPrivateKeyPEM(t)
+	data, err := NewSSHKeyData("deploy", pemKey, "ssh-ed25519 AAAA...", "", "ci deploy key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded models.SSHKeyData
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.PrivateKey != pemKey || decoded.Comment != "ci deploy key" || decoded.Name != "deploy" {
+		t.Fatalf("round trip lost data: %+v", decoded)
+	}
+}
+
+func TestSSHKeyDataRejectsGarbage(t *testing.T) {
+	if _, err := NewSSHKeyData("bad", "not a pem key at all", "", "", ""); err == nil {
+		t.Fatal("garbage private key accepted")
+	}
+}
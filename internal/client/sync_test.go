@@ -0,0 +1,290 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	"github.com/ar11/gophkeeper/internal/storage"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// newSyncClient returns a connected, unlocked client plus the server's
+// backing store and the registered user's ID, for seeding remote-side
+// changes.
+func newSyncClient(t *testing.T) (*Client, *storage.MemoryStorage, int64) {
+	t.Helper()
+	addr, store := startTestServer(t)
+	c := newTestClient(t)
+	if err := c.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Register("sync-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+	user, err := store.GetUserByLogin(context.Background(), "sync-user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, store, user.ID
+}
+
+func TestSyncPushesLocalAdditions(t *testing.T) {
+	c, store, userID := newSyncClient(t)
+
+	c.SetOffline(true)
+	if _, err := c.AddItem("laptop-note", "text", []byte("offline"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	c.SetOffline(false)
+
+	result, err := c.Sync(nil)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %v", result.Conflicts)
+	}
+	if _, err := store.GetItemByName(context.Background(), userID, "laptop-note"); err != nil {
+		t.Fatalf("local addition did not reach the server: %v", err)
+	}
+
+	// Running sync again must not duplicate or fail.
+	if _, err := c.Sync(nil); err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+}
+
+func TestSyncPullsRemoteAdditions(t *testing.T) {
+	c, store, userID := newSyncClient(t)
+
+	if _, err := store.AddItem(context.Background(), &models.Item{
+		UserID: userID, Name: "phone-note", Type: "text", Data: []byte("opaque"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.Sync(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, item := range result.Items {
+		if item.Name == "phone-note" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("remote addition not pulled")
+	}
+
+	// The pulled item is now readable offline.
+	c.SetOffline(true)
+	if item, ok := c.cachedItem("phone-note"); !ok || string(item.Data) != "opaque" {
+		t.Fatal("remote addition not cached locally")
+	}
+}
+
+func TestSyncPropagatesDeletions(t *testing.T) {
+	addr, _ := startTestServer(t)
+
+	// Two devices, one account: each client has its own config and
+	// cache directory but talks to the same server.
+	first := newTestClient(t)
+	if err := first.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { first.Close() })
+	if err := first.Register("shared-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+
+	second := newTestClient(t)
+	if err := second.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { second.Close() })
+	if err := second.Login("shared-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := first.AddItem("shared-note", "text", []byte("hello"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.Sync(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := second.cachedItem("shared-note"); !ok {
+		t.Fatal("item did not reach the second client")
+	}
+
+	if err := first.DeleteItem("shared-note"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := second.Sync(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0] != "shared-note" {
+		t.Fatalf("sync reported deletions %v, want [shared-note]", result.Deleted)
+	}
+	if _, ok := second.cachedItem("shared-note"); ok {
+		t.Fatal("deletion did not remove the item from the second client's cache")
+	}
+}
+
+func TestSyncBidirectional(t *testing.T) {
+	c, store, userID := newSyncClient(t)
+
+	// Remote side gains one item while this device adds another offline.
+	if _, err := store.AddItem(context.Background(), &models.Item{
+		UserID: userID, Name: "remote-item", Type: "text", Data: []byte("opaque"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	c.SetOffline(true)
+	if _, err := c.AddItem("local-item", "text", []byte("mine"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	c.SetOffline(false)
+
+	result, err := c.Sync(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %v", result.Conflicts)
+	}
+	if _, err := store.GetItemByName(context.Background(), userID, "local-item"); err != nil {
+		t.Fatalf("local item not pushed: %v", err)
+	}
+	names := map[string]bool{}
+	for _, item := range result.Items {
+		names[item.Name] = true
+	}
+	if !names["remote-item"] {
+		t.Fatalf("remote item not pulled, got %v", names)
+	}
+}
+
+func TestPreviewSyncReportsPlanWithoutApplying(t *testing.T) {
+	c, store, userID := newSyncClient(t)
+
+	c.SetOffline(true)
+	if _, err := c.AddItem("queued-note", "text", []byte("offline"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	c.SetOffline(false)
+	if _, err := store.AddItem(context.Background(), &models.Item{
+		UserID: userID, Name: "remote-note", Type: "text", Data: []byte("x"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := c.PreviewSync(nil)
+	if err != nil {
+		t.Fatalf("preview sync: %v", err)
+	}
+	if plan.Adds != 1 || plan.Updates != 0 || plan.Conflicts != 0 {
+		t.Fatalf("plan %+v, want 1 add only", plan)
+	}
+	if plan.Pulls != 1 {
+		t.Fatalf("plan reports %d pulls, want 1", plan.Pulls)
+	}
+
+	// Nothing moved: the queued write stayed local and pending.
+	if _, err := store.GetItemByName(context.Background(), userID, "queued-note"); err == nil {
+		t.Fatal("dry run pushed the queued item to the server")
+	}
+	pending, err := c.loadPending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("pending queue has %d entries after dry run, want 1", len(pending))
+	}
+}
+
+// mockPagedSyncClient serves a two-page pull so the client's cursor
+// loop can be exercised without seeding hundreds of items. The client
+// reuses one request across pages, so each call's fields are copied.
+type syncCall struct {
+	pageSize, cursorID int64
+	hasCursorAt        bool
+	localChanges       int
+}
+
+type mockPagedSyncClient struct {
+	pb.GophKeeperClient
+
+	calls []syncCall
+}
+
+func (m *mockPagedSyncClient) Sync(_ context.Context, req *pb.SyncRequest, _ ...grpc.CallOption) (*pb.SyncResponse, error) {
+	m.calls = append(m.calls, syncCall{
+		pageSize:     req.GetPageSize(),
+		cursorID:     req.GetCursorId(),
+		hasCursorAt:  req.GetCursorUpdatedAt() != nil,
+		localChanges: len(req.GetLocalChanges()),
+	})
+	at := timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	if len(m.calls) == 1 {
+		return &pb.SyncResponse{
+			Items: []*pb.Item{
+				{Id: 1, Name: "first", Type: models.TypeText, Version: 1, UpdatedAt: at},
+				{Id: 2, Name: "second", Type: models.TypeText, Version: 1, UpdatedAt: at},
+			},
+			More: true,
+		}, nil
+	}
+	return &pb.SyncResponse{
+		Items: []*pb.Item{
+			{Id: 3, Name: "third", Type: models.TypeText, Version: 1, UpdatedAt: at},
+		},
+	}, nil
+}
+
+func TestSyncFollowsPages(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockPagedSyncClient{}
+	c.WithGRPCClient(mock)
+
+	result, err := c.Sync(nil)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if len(result.Items) != 3 {
+		t.Fatalf("pulled %d items across pages, want 3", len(result.Items))
+	}
+	if len(mock.calls) != 2 {
+		t.Fatalf("made %d sync calls, want 2", len(mock.calls))
+	}
+	first, second := mock.calls[0], mock.calls[1]
+	if first.pageSize == 0 || first.cursorID != 0 {
+		t.Fatalf("first call = %+v, want a page size and no cursor", first)
+	}
+	if second.cursorID != 2 || !second.hasCursorAt {
+		t.Fatalf("second call = %+v, want the last item of page one as cursor", second)
+	}
+	if second.localChanges != 0 {
+		t.Fatal("local changes re-sent on a follow-up page")
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRPCTimeoutResolution(t *testing.T) {
+	c := newTestClient(t)
+	if got := c.rpcTimeout(); got != defaultRPCTimeout {
+		t.Fatalf("default timeout = %v, want %v", got, defaultRPCTimeout)
+	}
+
+	c.cfg.RPCTimeout = "45s"
+	if got := c.rpcTimeout(); got != 45*time.Second {
+		t.Fatalf("config timeout not applied: %v", got)
+	}
+
+	// An explicit override beats the config value.
+	c.SetRPCTimeout(3 * time.Second)
+	if got := c.rpcTimeout(); got != 3*time.Second {
+		t.Fatalf("override not applied: %v", got)
+	}
+
+	c.SetRPCTimeout(0)
+	c.cfg.RPCTimeout = "not a duration"
+	if got := c.rpcTimeout(); got != defaultRPCTimeout {
+		t.Fatalf("garbage config value not ignored: %v", got)
+	}
+}
+
+func TestRPCContextCarriesDeadline(t *testing.T) {
+	c := newTestClient(t)
+	c.SetRPCTimeout(5 * time.Second)
+
+	ctx, cancel := c.rpcContext()
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("rpc context has no deadline")
+	}
+	if d := time.Until(deadline); d <= 0 || d > 5*time.Second {
+		t.Fatalf("deadline %v away, want within 5s", d)
+	}
+
+	slowCtx, slowCancel := c.slowContext()
+	defer slowCancel()
+	slowDeadline, ok := slowCtx.Deadline()
+	if !ok {
+		t.Fatal("slow context has no deadline")
+	}
+	if d := time.Until(slowDeadline); d <= 5*time.Second || d > slowRPCFactor*5*time.Second {
+		t.Fatalf("slow deadline %v away, want within %v and beyond the unary budget", d, slowRPCFactor*5*time.Second)
+	}
+}
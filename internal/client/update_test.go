@@ -0,0 +1,196 @@
+package client
+
+import (
+	"time"
+
+	"bytes"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/ar11/gophkeeper/internal/server"
+	"github.com/ar11/gophkeeper/internal/storage"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// startTestServer runs a real gRPC server over in-memory storage and
+// returns its address.
+func startTestServer(t *testing.T) (string, *storage.MemoryStorage) {
+	return startConfiguredServer(t, nil)
+}
+
+// startConfiguredServer is startTestServer with a hook to tweak the
+// server before it begins serving.
+func startConfiguredServer(t *testing.T, configure func(*server.Server), extra ...grpc.ServerOption) (string, *storage.MemoryStorage) {
+	t.Helper()
+	store := storage.NewMemoryStorage()
+	srv := server.New(store, []byte("test-secret"))
+	if configure != nil {
+		configure(srv)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := append([]grpc.ServerOption{
+		grpc.UnaryInterceptor(srv.AuthInterceptor()),
+		grpc.StreamInterceptor(srv.AuthStreamInterceptor()),
+	}, extra...)
+	grpcServer := grpc.NewServer(opts...)
+	pb.RegisterGophKeeperServer(grpcServer, srv)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	return listener.Addr().String(), store
+}
+
+// newConnectedClient returns a client registered and unlocked against a
+// fresh test server.
+func newConnectedClient(t *testing.T) *Client {
+	t.Helper()
+	addr, _ := startTestServer(t)
+	c := newTestClient(t)
+	if err := c.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Register("edit-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestUpdateItem(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("mail", "login_password", []byte("old-secret"), map[string]string{"site": "example.com"}, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.UpdateItem("mail", "", []byte("new-secret"), nil, nil); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	item, plaintext, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "new-secret" {
+		t.Fatalf("got %q after edit, want new-secret", plaintext)
+	}
+	if item.Version != 2 {
+		t.Fatalf("version = %d after one edit, want 2", item.Version)
+	}
+	if item.Metadata["site"] != "example.com" {
+		t.Fatal("metadata not preserved across an edit")
+	}
+}
+
+func TestUpdateItemStaleVersion(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("mail", "login_password", []byte("v1"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Fetch the item, then let a concurrent edit land first.
+	stale, _, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateItem("mail", "", []byte("concurrent"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sending the stale version must surface the conflict, not clobber.
+	if err := c.updateItem(stale, []byte("lost-update")); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("stale edit: got %v, want ErrVersionConflict", err)
+	}
+	_, plaintext, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "concurrent" {
+		t.Fatalf("stale edit overwrote data: got %q", plaintext)
+	}
+}
+
+func TestCompressedItemRoundTrip(t *testing.T) {
+	c := newConnectedClient(t)
+	c.SetCompression(true)
+	plaintext := bytes.Repeat([]byte("compress me, compress me\n"), 500)
+	if _, err := c.AddItem("big-note", "text", plaintext, nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	item, got, err := c.GetItem("big-note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatal("compressed item round trip lost data")
+	}
+	if len(item.Data) >= len(plaintext) {
+		t.Fatalf("stored blob is %d bytes, plaintext %d; compression had no effect", len(item.Data), len(plaintext))
+	}
+}
+
+func TestAddItemDuplicateNameReturnsTypedError(t *testing.T) {
+	c := newConnectedClient(t)
+	if _, err := c.AddItem("mail", "text", []byte("first"), nil, nil, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := c.AddItem("mail", "text", []byte("second"), nil, nil, time.Time{})
+	var dup *DuplicateItemError
+	if !errors.As(err, &dup) {
+		t.Fatalf("got %v, want DuplicateItemError", err)
+	}
+	if dup.Name != "mail" || dup.CreatedAt.IsZero() {
+		t.Fatalf("incomplete duplicate report: %+v", dup)
+	}
+
+	// The flow the CLI offers instead: update the existing item.
+	if err := c.UpdateItem("mail", "", []byte("second"), nil, nil); err != nil {
+		t.Fatalf("update instead: %v", err)
+	}
+	if _, plaintext, err := c.GetItem("mail"); err != nil || string(plaintext) != "second" {
+		t.Fatalf("update-instead left %q, %v", plaintext, err)
+	}
+}
+
+func TestRotateItemChangesOnlyTheSecret(t *testing.T) {
+	c := newConnectedClient(t)
+	meta := map[string]string{"login": "me@example.com", "site": "example.com"}
+	if _, err := c.AddItem("mail", "login_password", []byte("old-pw"), meta, []string{"work"}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	before, _, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.RotateItem("mail", []byte("new-pw")); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+
+	after, plaintext, err := c.GetItem("mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "new-pw" {
+		t.Fatalf("secret is %q after rotation, want new-pw", plaintext)
+	}
+	if after.Type != before.Type {
+		t.Fatalf("rotation changed the type: %q -> %q", before.Type, after.Type)
+	}
+	if !reflect.DeepEqual(after.Metadata, meta) || !reflect.DeepEqual(after.Tags, []string{"work"}) {
+		t.Fatalf("rotation touched metadata or tags: %v %v", after.Metadata, after.Tags)
+	}
+	if after.Version != before.Version+1 {
+		t.Fatalf("version went %d -> %d, want a single bump", before.Version, after.Version)
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// uploadChunkSize mirrors the server's download chunk size.
+const uploadChunkSize = 64 * 1024
+
+// UploadItem stream-encrypts r under the master password and uploads it
+// as a new item, without ever holding the whole payload in memory on
+// the client. The payload uses the framed streaming format, so
+// DownloadItem is the matching read path. progress, when non-nil, is
+// called with the encrypted bytes sent so far. Like DownloadItem the
+// stream context has no deadline.
+func (c *Client) UploadItem(name, itemType string, r io.Reader, metadata map[string]string, tags []string, expiresAt time.Time, progress func(sent int64)) (int64, error) {
+	if err := c.requireOnline(); err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := c.rpc.UploadItem(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("upload item: %w", err)
+	}
+
+	item := &models.Item{Name: name, Type: itemType, Metadata: metadata, Tags: tags, ExpiresAt: expiresAt}
+	if err := stream.Send(&pb.UploadItemChunk{Item: itemToProto(item)}); err != nil {
+		return 0, fmt.Errorf("upload item: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(crypto.EncryptStream(c.masterPassword(), r, pw))
+	}()
+
+	var sent int64
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, readErr := pr.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.UploadItemChunk{Data: buf[:n]}); err != nil {
+				pr.CloseWithError(err)
+				// A failed Send reports io.EOF; the real status
+				// comes from closing the stream.
+				if _, rerr := stream.CloseAndRecv(); rerr != nil {
+					err = rerr
+				}
+				return 0, fmt.Errorf("upload item: %w", err)
+			}
+			sent += int64(n)
+			if progress != nil {
+				progress(sent)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return 0, fmt.Errorf("encrypt item: %w", readErr)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, fmt.Errorf("upload item: %w", err)
+	}
+	return resp.GetId(), nil
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	"github.com/ar11/gophkeeper/internal/server"
+)
+
+func TestUploadItemRoundTrip(t *testing.T) {
+	c, store, userID := newSyncClient(t)
+
+	// Several streaming frames' worth of data.
+	plaintext := make([]byte, 300*1024)
+	if _, err := rand.Read(plaintext); err != nil {
+		t.Fatal(err)
+	}
+
+	var lastSent int64
+	id, err := c.UploadItem("backup", models.TypeBinary, bytes.NewReader(plaintext), nil, nil, time.Time{}, func(sent int64) {
+		lastSent = sent
+	})
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	if id == 0 {
+		t.Fatal("upload returned no item ID")
+	}
+	if lastSent == 0 {
+		t.Fatal("progress was never reported")
+	}
+	if _, err := store.GetItemByName(context.Background(), userID, "backup"); err != nil {
+		t.Fatalf("uploaded item not stored: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.bin")
+	if err := c.DownloadItem("backup", path, nil); err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatal("uploaded item did not read back identically")
+	}
+}
+
+func TestUploadItemSizeLimit(t *testing.T) {
+	addr, _ := startConfiguredServer(t, func(srv *server.Server) {
+		srv.SetMaxUploadSize(1024)
+	})
+	c := newTestClient(t)
+	if err := c.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Register("upload-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyMasterPassword("master"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := c.UploadItem("too-big", models.TypeBinary, bytes.NewReader(make([]byte, 1<<20)), nil, nil, time.Time{}, nil)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("oversized upload = %v, want ResourceExhausted", err)
+	}
+	if _, total, err := c.ListItems(0, 0, nil, nil, "", ""); err != nil || total != 0 {
+		t.Fatalf("partial upload left something behind: total=%d err=%v", total, err)
+	}
+}
+
+func TestUploadItemValidatesMetadata(t *testing.T) {
+	c, _, _ := newSyncClient(t)
+
+	// The server applies the same name/type checks as a unary AddItem.
+	for name, args := range map[string][2]string{
+		"blank name":   {"   ", models.TypeBinary},
+		"unknown type": {"bird", "carrier_pigeon"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.UploadItem(args[0], args[1], bytes.NewReader([]byte("x")), nil, nil, time.Time{}, nil)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("got %v, want InvalidArgument", err)
+			}
+			if _, total, err := c.ListItems(0, 0, nil, nil, "", ""); err != nil || total != 0 {
+				t.Fatalf("invalid upload left something behind: total=%d err=%v", total, err)
+			}
+		})
+	}
+}
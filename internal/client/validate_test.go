@@ -0,0 +1,37 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddItemRejectsInvalidInputLocally(t *testing.T) {
+	c := newTestClient(t)
+	c.setMasterPassword("master")
+
+	// No Connect: validation must fail before any server traffic.
+	for name, item := range map[string]struct {
+		name string
+		meta map[string]string
+	}{
+		"blank name":        {name: "   "},
+		"overlong name":     {name: strings.Repeat("a", 300)},
+		"blank meta key":    {name: "ok", meta: map[string]string{" ": "x"}},
+		"overlong meta val": {name: "ok", meta: map[string]string{"k": strings.Repeat("v", 2000)}},
+	} {
+		if _, err := c.AddItem(item.name, "text", []byte("x"), item.meta, nil, time.Time{}); err == nil {
+			t.Fatalf("%s was accepted", name)
+		}
+	}
+}
+
+func TestRegisterRejectsBlankLogin(t *testing.T) {
+	c := newTestClient(t)
+	if err := c.Register("   ", "long-enough-pw"); err == nil {
+		t.Fatal("whitespace-only login was accepted")
+	}
+	if err := c.Login("", "long-enough-pw"); err == nil {
+		t.Fatal("empty login was accepted")
+	}
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWhoamiReportsUserAndExpiry(t *testing.T) {
+	addr, _ := startTestServer(t)
+	c := newTestClient(t)
+	if err := c.Connect(addr, "", true); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if err := c.Register("whoami-user", "long-enough-pw"); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := c.Whoami()
+	if err != nil {
+		t.Fatalf("whoami: %v", err)
+	}
+	if profile.Login != "whoami-user" {
+		t.Fatalf("login = %q, want whoami-user", profile.Login)
+	}
+	if profile.UserID == 0 {
+		t.Fatal("user id not set")
+	}
+	if !profile.TokenExpiresAt.After(time.Now()) {
+		t.Fatalf("fresh token already expired: %v", profile.TokenExpiresAt)
+	}
+}
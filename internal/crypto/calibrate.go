@@ -0,0 +1,46 @@
+package crypto
+
+import (
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// CalibrateIterations benchmarks the Argon2id configuration used by
+// DeriveKey on the current machine and returns the time cost (number of
+// passes) that brings one derivation closest to target from below,
+// never returning less than 1 or more than the header's maximum.
+// Derivation time scales linearly with the pass count, so a single pass
+// is timed and the rest is arithmetic.
+//
+// The result is machine-specific and meant to be passed to
+// EncryptWithPasswordCost, which records it in the blob header so
+// decryption works with it anywhere: blobs written on a fast machine
+// must still be decryptable on a slow one.
+func CalibrateIterations(target time.Duration) int {
+	salt := make([]byte, SaltSize)
+	const probe = "calibration probe"
+
+	// Warm-up pass so one-time allocations don't skew the timing.
+	argon2.IDKey([]byte(probe), salt, 1, argonMemory, argonThreads, KeySize)
+
+	// Take the fastest of a few samples: scheduling noise only ever
+	// makes a sample slower, never faster.
+	perPass := time.Duration(1<<63 - 1)
+	for i := 0; i < 3; i++ {
+		start := time.Now()
+		argon2.IDKey([]byte(probe), salt, 1, argonMemory, argonThreads, KeySize)
+		if elapsed := time.Since(start); elapsed < perPass {
+			perPass = elapsed
+		}
+	}
+
+	iterations := int(target / perPass)
+	if iterations < 1 {
+		return 1
+	}
+	if iterations > maxKDFTimeCost {
+		return maxKDFTimeCost
+	}
+	return iterations
+}
@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestCalibrateIterations(t *testing.T) {
+	const target = 150 * time.Millisecond
+	iterations := CalibrateIterations(target)
+	if iterations < 1 {
+		t.Fatalf("iterations = %d, want at least 1", iterations)
+	}
+
+	salt := make([]byte, SaltSize)
+	start := time.Now()
+	argon2.IDKey([]byte("calibration probe"), salt, uint32(iterations), argonMemory, argonThreads, KeySize)
+	elapsed := time.Since(start)
+
+	// Very loose band: CI machines are noisy, and a single pass that
+	// already overshoots the target is legitimately returned as 1.
+	if iterations > 1 && elapsed < target/5 {
+		t.Errorf("derivation took %v, suspiciously far below the %v target", elapsed, target)
+	}
+	if elapsed > 5*target {
+		t.Errorf("derivation took %v, far above the %v target", elapsed, target)
+	}
+}
+
+func TestCalibratedCostRoundTrips(t *testing.T) {
+	// The calibrated cost feeds straight into the blob header and back
+	// out at decryption time.
+	cost := CalibrateIterations(10 * time.Millisecond)
+	blob, err := EncryptWithPasswordCost("pw", []byte("tuned"), nil, SuiteAESGCM, uint32(cost))
+	if err != nil {
+		t.Fatalf("encrypt with calibrated cost %d: %v", cost, err)
+	}
+	got, err := DecryptWithPassword("pw", blob)
+	if err != nil {
+		t.Fatalf("decrypt with calibrated cost %d: %v", cost, err)
+	}
+	if string(got) != "tuned" {
+		t.Fatalf("unexpected plaintext %q", got)
+	}
+}
+
+func TestCalibrateIterationsTinyTarget(t *testing.T) {
+	// A target below one pass must still yield a usable cost.
+	if got := CalibrateIterations(time.Nanosecond); got != 1 {
+		t.Fatalf("iterations = %d, want 1 for an unreachable target", got)
+	}
+}
@@ -0,0 +1,11 @@
+package crypto
+
+import "crypto/subtle"
+
+// ConstantTimeEqual reports whether a and b are equal without leaking
+// timing information about where they differ. Use it for any comparison
+// involving secrets or MAC-like values; == and bytes.Equal short-circuit
+// on the first mismatch and are therefore unsuitable.
+func ConstantTimeEqual(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
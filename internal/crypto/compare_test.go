@@ -0,0 +1,22 @@
+package crypto
+
+import "testing"
+
+func TestConstantTimeEqual(t *testing.T) {
+	cases := []struct {
+		a, b []byte
+		want bool
+	}{
+		{[]byte("secret"), []byte("secret"), true},
+		{[]byte("secret"), []byte("secreT"), false},
+		{[]byte("secret"), []byte("secret2"), false},
+		{nil, nil, true},
+		{[]byte{}, nil, true},
+		{[]byte("a"), nil, false},
+	}
+	for _, c := range cases {
+		if got := ConstantTimeEqual(c.a, c.b); got != c.want {
+			t.Errorf("ConstantTimeEqual(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
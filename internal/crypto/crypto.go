@@ -0,0 +1,348 @@
+// Package crypto provides the symmetric encryption primitives used to
+// protect user secrets. All data is encrypted client-side with a key
+// derived from the user's master password, so the server only ever sees
+// opaque ciphertext.
+package crypto
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// SaltSize is the length in bytes of the random salt prepended to
+	// every encrypted blob.
+	SaltSize = 16
+
+	// KeySize is the length in bytes of keys derived by DeriveKey.
+	KeySize = 32
+)
+
+// Argon2id parameters. The time cost is only the default: blobs sealed
+// with a different pass count record it in their header, so it can vary
+// per item without a migration. Memory and parallelism are fixed;
+// changing them requires a new KDF id so old blobs stay decryptable.
+const (
+	argonTime    = 1
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+)
+
+// KDF identifiers recorded in blob headers that carry explicit
+// key-derivation parameters.
+const (
+	kdfArgon2id byte = 1
+)
+
+// Format header constants. Every blob produced by EncryptWithPassword
+// starts with the two magic bytes followed by a version byte whose low
+// bits name the cipher suite and whose high bits flag optional header
+// extensions, so the key-derivation and cipher parameters can evolve
+// without breaking existing data. Blobs without the magic bytes are
+// treated as legacy v0 (raw salt|nonce|ciphertext).
+const (
+	magic0 = 'G'
+	magic1 = 'K'
+
+	// VersionAESGCM is format version 1: Argon2id key derivation with
+	// AES-256-GCM encryption.
+	VersionAESGCM byte = 1
+
+	// VersionChaCha20Poly1305 is format version 2: Argon2id key
+	// derivation with ChaCha20-Poly1305 encryption. Preferable on
+	// devices without AES hardware acceleration.
+	VersionChaCha20Poly1305 byte = 2
+)
+
+// Suite selects the AEAD cipher suite used by EncryptWithPasswordSuite.
+// The chosen suite is recorded in the blob header, so decryption picks
+// the right primitive automatically.
+type Suite byte
+
+// Available cipher suites.
+const (
+	SuiteAESGCM           = Suite(VersionAESGCM)
+	SuiteChaCha20Poly1305 = Suite(VersionChaCha20Poly1305)
+)
+
+const headerSize = 3 // magic (2) + version (1)
+
+// kdfParamsSize is the length of the optional KDF block following the
+// version byte: KDF id (1) plus big-endian time cost (4).
+const kdfParamsSize = 5
+
+// maxKDFTimeCost bounds the time cost accepted from a blob header, so a
+// corrupted or hostile blob cannot make decryption grind through
+// billions of Argon2id passes.
+const maxKDFTimeCost = 1 << 16
+
+// compressedFlag is OR-ed into the header version byte when the
+// plaintext was gzip-compressed before sealing, leaving the low bits
+// for the cipher suite. Decryption strips the flag, decrypts, then
+// decompresses.
+const compressedFlag byte = 0x80
+
+// kdfParamsFlag is OR-ed into the header version byte when the KDF id
+// and time cost follow the header. Blobs without the flag were sealed
+// with the package defaults.
+const kdfParamsFlag byte = 0x40
+
+// ErrInvalidFormat is returned when a blob does not carry the expected
+// header or is too short to contain one.
+var ErrInvalidFormat = errors.New("crypto: invalid encrypted data format")
+
+// ErrDecryptionFailed is returned when a blob fails authentication:
+// wrong password, corrupted data, or associated data that does not
+// match the one used at encryption time.
+var ErrDecryptionFailed = errors.New("crypto: decryption failed")
+
+// DeriveKey derives a KeySize-byte encryption key from the master
+// password and salt using Argon2id with the default time cost.
+func DeriveKey(password string, salt []byte) []byte {
+	return DeriveKeyCost(password, salt, argonTime)
+}
+
+// DeriveKeyCost is like DeriveKey but with an explicit Argon2id time
+// cost (number of passes), as recorded in a blob header or returned by
+// CalibrateIterations.
+func DeriveKeyCost(password string, salt []byte, timeCost uint32) []byte {
+	return argon2.IDKey([]byte(password), salt, timeCost, argonMemory, argonThreads, KeySize)
+}
+
+// EncryptWithPassword encrypts plaintext with a key derived from
+// password using the default AES-GCM suite. The output layout is:
+//
+//	magic (2) | version (1) | [kdf id (1) | time cost (4)] | salt (16) | nonce (12) | ciphertext
+//
+// The bracketed KDF block appears only when the blob was sealed with a
+// non-default time cost (see EncryptWithPasswordCost).
+func EncryptWithPassword(password string, plaintext []byte) ([]byte, error) {
+	return EncryptWithPasswordAAD(password, plaintext, nil, SuiteAESGCM)
+}
+
+// EncryptWithPasswordSuite is like EncryptWithPassword but lets the
+// caller pick the AEAD cipher suite.
+func EncryptWithPasswordSuite(password string, plaintext []byte, suite Suite) ([]byte, error) {
+	return EncryptWithPasswordAAD(password, plaintext, nil, suite)
+}
+
+// EncryptWithPasswordAAD is like EncryptWithPasswordSuite but
+// additionally binds the ciphertext to aad (associated data). Callers
+// pass a stable item identifier, such as the item name, so a blob moved
+// to a different item fails to decrypt. The aad is authenticated but
+// not stored; DecryptWithPasswordAAD must be given the same value.
+func EncryptWithPasswordAAD(password string, plaintext, aad []byte, suite Suite) ([]byte, error) {
+	return EncryptWithPasswordCost(password, plaintext, aad, suite, argonTime)
+}
+
+// EncryptWithPasswordCost is like EncryptWithPasswordAAD but derives
+// the key with an explicit Argon2id time cost, typically one returned
+// by CalibrateIterations. A non-default cost is recorded in the blob
+// header, so decryption on any machine picks it up automatically.
+func EncryptWithPasswordCost(password string, plaintext, aad []byte, suite Suite, timeCost uint32) ([]byte, error) {
+	if timeCost < 1 {
+		timeCost = argonTime
+	}
+	salt := make([]byte, SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	key := DeriveKeyCost(password, salt, timeCost)
+	defer Zeroize(key)
+	aead, err := newAEAD(key, byte(suite))
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, headerSize+kdfParamsSize+SaltSize+len(nonce)+len(plaintext)+aead.Overhead())
+	if timeCost == argonTime {
+		// Default parameters keep the compact header old clients parse.
+		out = append(out, magic0, magic1, byte(suite))
+	} else {
+		out = append(out, magic0, magic1, byte(suite)|kdfParamsFlag, kdfArgon2id)
+		out = binary.BigEndian.AppendUint32(out, timeCost)
+	}
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, aad), nil
+}
+
+// EncryptWithPasswordAADCompressed is like EncryptWithPasswordAAD but
+// gzip-compresses the plaintext first, keeping whichever of the two is
+// smaller so incompressible data pays no size penalty. The choice is
+// recorded in the blob header and DecryptWithPasswordAAD undoes it
+// transparently.
+func EncryptWithPasswordAADCompressed(password string, plaintext, aad []byte, suite Suite) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(plaintext); err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress: %w", err)
+	}
+	if buf.Len() >= len(plaintext) {
+		return EncryptWithPasswordAAD(password, plaintext, aad, suite)
+	}
+	blob, err := EncryptWithPasswordAAD(password, buf.Bytes(), aad, suite)
+	if err != nil {
+		return nil, err
+	}
+	blob[2] |= compressedFlag
+	return blob, nil
+}
+
+// DecryptWithPassword reverses EncryptWithPassword. It validates the
+// header, re-derives the key from the embedded salt using the KDF
+// parameters the header records (or the defaults when it records none)
+// and decrypts the payload. Blobs missing the magic bytes are decoded
+// as legacy v0. An unknown version is reported explicitly so newer
+// clients can give a useful error instead of a generic authentication
+// failure.
+func DecryptWithPassword(password string, data []byte) ([]byte, error) {
+	return DecryptWithPasswordAAD(password, data, nil)
+}
+
+// DecryptWithPasswordAAD reverses EncryptWithPasswordAAD. The aad must
+// match the value passed at encryption time; otherwise
+// ErrDecryptionFailed is returned. Blobs written before associated data
+// was introduced were sealed with no AAD, so when the aad-bound attempt
+// fails the blob is retried without it to keep old items readable.
+func DecryptWithPasswordAAD(password string, data, aad []byte) ([]byte, error) {
+	if len(data) >= headerSize && (data[0] != magic0 || data[1] != magic1) {
+		// Legacy v0 blobs predate the header entirely: raw
+		// salt|nonce|ciphertext sealed with AES-GCM and default
+		// parameters.
+		return decryptLegacy(password, data, aad)
+	}
+	if len(data) < headerSize {
+		return nil, ErrInvalidFormat
+	}
+	version := data[2]
+	compressed := version&compressedFlag != 0
+	version &^= compressedFlag
+	body := data[headerSize:]
+
+	timeCost := uint32(argonTime)
+	if version&kdfParamsFlag != 0 {
+		version &^= kdfParamsFlag
+		if len(body) < kdfParamsSize {
+			return nil, ErrInvalidFormat
+		}
+		if body[0] != kdfArgon2id {
+			return nil, fmt.Errorf("crypto: unsupported KDF id %d", body[0])
+		}
+		timeCost = binary.BigEndian.Uint32(body[1:kdfParamsSize])
+		if timeCost < 1 || timeCost > maxKDFTimeCost {
+			return nil, ErrInvalidFormat
+		}
+		body = body[kdfParamsSize:]
+	}
+
+	if len(body) < SaltSize {
+		return nil, ErrInvalidFormat
+	}
+	salt, body := body[:SaltSize], body[SaltSize:]
+
+	key := DeriveKeyCost(password, salt, timeCost)
+	defer Zeroize(key)
+	aead, err := newAEAD(key, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) < aead.NonceSize() {
+		return nil, ErrInvalidFormat
+	}
+	nonce, ciphertext := body[:aead.NonceSize()], body[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil && len(aad) > 0 {
+		// Legacy path: items stored before AAD binding existed.
+		plaintext, err = aead.Open(nil, nonce, ciphertext, nil)
+	}
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	if compressed {
+		zr, err := gzip.NewReader(bytes.NewReader(plaintext))
+		if err != nil {
+			return nil, fmt.Errorf("decompress: %w", err)
+		}
+		plaintext, err = io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("decompress: %w", err)
+		}
+		if err := zr.Close(); err != nil {
+			return nil, fmt.Errorf("decompress: %w", err)
+		}
+	}
+	return plaintext, nil
+}
+
+// decryptLegacy decodes v0 blobs written before the versioned header
+// existed: raw salt (16) | nonce (12) | ciphertext, sealed with AES-GCM
+// and the default KDF parameters. AAD binding also postdates v0, so the
+// aad argument is ignored.
+func decryptLegacy(password string, data, _ []byte) ([]byte, error) {
+	if len(data) < SaltSize {
+		return nil, ErrInvalidFormat
+	}
+	salt, body := data[:SaltSize], data[SaltSize:]
+
+	key := DeriveKey(password, salt)
+	defer Zeroize(key)
+	aead, err := newAEAD(key, VersionAESGCM)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidFormat
+	}
+	nonce, ciphertext := body[:aead.NonceSize()], body[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte, version byte) (cipher.AEAD, error) {
+	switch version {
+	case VersionAESGCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("create cipher: %w", err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("create GCM: %w", err)
+		}
+		return aead, nil
+	case VersionChaCha20Poly1305:
+		aead, err := chacha20poly1305.New(key)
+		if err != nil {
+			return nil, fmt.Errorf("create ChaCha20-Poly1305: %w", err)
+		}
+		return aead, nil
+	default:
+		return nil, fmt.Errorf("crypto: unsupported format version %d", version)
+	}
+}
@@ -0,0 +1,250 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	suites := map[string]Suite{
+		"aes-gcm":           SuiteAESGCM,
+		"chacha20-poly1305": SuiteChaCha20Poly1305,
+	}
+	plaintext := []byte("my secret login and password")
+
+	for name, suite := range suites {
+		t.Run(name, func(t *testing.T) {
+			blob, err := EncryptWithPasswordSuite("master-password", plaintext, suite)
+			if err != nil {
+				t.Fatalf("encrypt: %v", err)
+			}
+			if blob[0] != magic0 || blob[1] != magic1 || blob[2] != byte(suite) {
+				t.Fatalf("unexpected header: %v", blob[:headerSize])
+			}
+
+			got, err := DecryptWithPassword("master-password", blob)
+			if err != nil {
+				t.Fatalf("decrypt: %v", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Fatalf("round trip mismatch: got %q, want %q", got, plaintext)
+			}
+		})
+	}
+}
+
+func TestDecryptWrongPassword(t *testing.T) {
+	blob, err := EncryptWithPassword("right", []byte("secret"))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if _, err := DecryptWithPassword("wrong", blob); err == nil {
+		t.Fatal("expected decryption with wrong password to fail")
+	}
+}
+
+func TestDecryptInvalidFormat(t *testing.T) {
+	for _, data := range [][]byte{nil, {'G'}, {'X', 'Y', 1, 2, 3}} {
+		if _, err := DecryptWithPassword("pw", data); err == nil {
+			t.Fatalf("expected error for malformed input %v", data)
+		}
+	}
+}
+
+func TestDecryptUnknownVersion(t *testing.T) {
+	blob, err := EncryptWithPassword("pw", []byte("secret"))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	blob[2] = 0xFF
+	if _, err := DecryptWithPassword("pw", blob); err == nil {
+		t.Fatal("expected error for unknown format version")
+	}
+}
+
+func TestDecryptLegacyV0Blob(t *testing.T) {
+	// Build a blob the way the pre-header format did: raw
+	// salt|nonce|ciphertext, AES-GCM, default KDF parameters.
+	plaintext := []byte("written before headers existed")
+	salt := make([]byte, SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		t.Fatal(err)
+	}
+	key := DeriveKey("pw", salt)
+	aead, err := newAEAD(key, VersionAESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		t.Fatal(err)
+	}
+	blob := append(append([]byte{}, salt...), nonce...)
+	blob = aead.Seal(blob, nonce, plaintext, nil)
+
+	got, err := DecryptWithPassword("pw", blob)
+	if err != nil {
+		t.Fatalf("decrypt v0 blob: %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatalf("v0 decode mismatch: got %q, want %q", got, plaintext)
+	}
+}
+
+func TestDecryptV1Blob(t *testing.T) {
+	// A v1 blob has the compact header and default KDF parameters.
+	blob, err := EncryptWithPassword("pw", []byte("headered secret"))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if blob[0] != magic0 || blob[1] != magic1 || blob[2] != VersionAESGCM {
+		t.Fatalf("unexpected v1 header: %v", blob[:headerSize])
+	}
+	got, err := DecryptWithPassword("pw", blob)
+	if err != nil {
+		t.Fatalf("decrypt v1 blob: %v", err)
+	}
+	if string(got) != "headered secret" {
+		t.Fatalf("v1 decode mismatch: got %q", got)
+	}
+}
+
+func TestHeaderRecordsKDFTimeCost(t *testing.T) {
+	blob, err := EncryptWithPasswordCost("pw", []byte("tuned secret"), []byte("item"), SuiteAESGCM, 3)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if blob[2] != byte(SuiteAESGCM)|kdfParamsFlag {
+		t.Fatalf("version byte %#x, want the KDF params flag set", blob[2])
+	}
+	if blob[3] != kdfArgon2id {
+		t.Fatalf("kdf id = %d, want %d", blob[3], kdfArgon2id)
+	}
+	if cost := binary.BigEndian.Uint32(blob[4:8]); cost != 3 {
+		t.Fatalf("recorded time cost = %d, want 3", cost)
+	}
+
+	got, err := DecryptWithPasswordAAD("pw", blob, []byte("item"))
+	if err != nil {
+		t.Fatalf("decrypt with header-selected cost: %v", err)
+	}
+	if string(got) != "tuned secret" {
+		t.Fatalf("unexpected plaintext %q", got)
+	}
+}
+
+func TestDecryptRejectsBadKDFParams(t *testing.T) {
+	blob, err := EncryptWithPasswordCost("pw", []byte("secret"), nil, SuiteAESGCM, 2)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	unknownKDF := append([]byte{}, blob...)
+	unknownKDF[3] = 0x7F
+	if _, err := DecryptWithPassword("pw", unknownKDF); err == nil {
+		t.Fatal("expected error for unknown KDF id")
+	}
+
+	hugeCost := append([]byte{}, blob...)
+	binary.BigEndian.PutUint32(hugeCost[4:8], 1<<31)
+	if _, err := DecryptWithPassword("pw", hugeCost); !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("expected ErrInvalidFormat for an absurd time cost, got %v", err)
+	}
+}
+
+func benchmarkSuite(b *testing.B, suite Suite) {
+	plaintext := bytes.Repeat([]byte("a"), 4096)
+	salt := make([]byte, SaltSize)
+	key := DeriveKey("master-password", salt)
+	aead, err := newAEAD(key, byte(suite))
+	if err != nil {
+		b.Fatal(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	b.SetBytes(int64(len(plaintext)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aead.Seal(nil, nonce, plaintext, nil)
+	}
+}
+
+func BenchmarkSealAESGCM(b *testing.B) {
+	benchmarkSuite(b, SuiteAESGCM)
+}
+
+func BenchmarkSealChaCha20Poly1305(b *testing.B) {
+	benchmarkSuite(b, SuiteChaCha20Poly1305)
+}
+
+func TestAADBindsCiphertextToItem(t *testing.T) {
+	blobA, err := EncryptWithPasswordAAD("pw", []byte("secret A"), []byte("item-a"), SuiteAESGCM)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	// Correct AAD decrypts.
+	if _, err := DecryptWithPasswordAAD("pw", blobA, []byte("item-a")); err != nil {
+		t.Fatalf("decrypt with matching AAD: %v", err)
+	}
+
+	// A blob swapped under another item's name must fail.
+	if _, err := DecryptWithPasswordAAD("pw", blobA, []byte("item-b")); !errors.Is(err, ErrDecryptionFailed) {
+		t.Fatalf("expected ErrDecryptionFailed for swapped blob, got %v", err)
+	}
+}
+
+func TestAADLegacyBlobStillReadable(t *testing.T) {
+	legacy, err := EncryptWithPassword("pw", []byte("old secret"))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	got, err := DecryptWithPasswordAAD("pw", legacy, []byte("item-name"))
+	if err != nil {
+		t.Fatalf("decrypt legacy blob with AAD: %v", err)
+	}
+	if string(got) != "old secret" {
+		t.Fatalf("unexpected plaintext %q", got)
+	}
+}
+
+func TestEncryptCompressedRoundTrip(t *testing.T) {
+	compressible := bytes.Repeat([]byte("the same line over and over\n"), 200)
+	blob, err := EncryptWithPasswordAADCompressed("pw", compressible, []byte("item"), SuiteAESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blob) >= len(compressible) {
+		t.Fatalf("compressible payload did not shrink: blob %d, plaintext %d", len(blob), len(compressible))
+	}
+	got, err := DecryptWithPasswordAAD("pw", blob, []byte("item"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, compressible) {
+		t.Fatal("compressed round trip lost data")
+	}
+}
+
+func TestEncryptCompressedSkipsIncompressible(t *testing.T) {
+	incompressible := make([]byte, 4096)
+	if _, err := rand.Read(incompressible); err != nil {
+		t.Fatal(err)
+	}
+	blob, err := EncryptWithPasswordAADCompressed("pw", incompressible, []byte("item"), SuiteAESGCM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blob[2]&0x80 != 0 {
+		t.Fatal("incompressible payload was stored compressed")
+	}
+	got, err := DecryptWithPasswordAAD("pw", blob, []byte("item"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, incompressible) {
+		t.Fatal("incompressible round trip lost data")
+	}
+}
@@ -0,0 +1,34 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// itemKeyInfoPrefix namespaces HKDF expansion so item keys can never
+// collide with other key material derived from the same master key.
+const itemKeyInfoPrefix = "gophkeeper item key v1:"
+
+// DeriveMasterKey derives the long-lived master key from the master
+// password and a persistent salt. This is the expensive step (Argon2id)
+// and should be done once per session; per-item keys are then derived
+// cheaply with DeriveItemKey.
+func DeriveMasterKey(password string, salt []byte) []byte {
+	return DeriveKey(password, salt)
+}
+
+// DeriveItemKey derives a deterministic per-item subkey from the master
+// key using HKDF-SHA256 with the item ID as context. Different item IDs
+// yield independent keys, so compromising one item key reveals nothing
+// about the others or the master key.
+func DeriveItemKey(masterKey []byte, itemID string) ([]byte, error) {
+	r := hkdf.New(sha256.New, masterKey, nil, []byte(itemKeyInfoPrefix+itemID))
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(r, key); err != nil {
+		return nil, fmt.Errorf("derive item key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,41 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDeriveItemKeyDeterministic(t *testing.T) {
+	master := DeriveMasterKey("pw", make([]byte, SaltSize))
+
+	k1, err := DeriveItemKey(master, "item-42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := DeriveItemKey(master, "item-42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k1, k2) {
+		t.Fatal("same inputs produced different keys")
+	}
+	if len(k1) != KeySize {
+		t.Fatalf("key length = %d, want %d", len(k1), KeySize)
+	}
+}
+
+func TestDeriveItemKeyDistinctPerItem(t *testing.T) {
+	master := DeriveMasterKey("pw", make([]byte, SaltSize))
+
+	k1, err := DeriveItemKey(master, "item-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := DeriveItemKey(master, "item-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(k1, k2) {
+		t.Fatal("different item IDs produced the same key")
+	}
+}
@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DefaultMinPasswordLength is the minimum account-password length
+// enforced when no explicit policy is configured.
+const DefaultMinPasswordLength = 8
+
+// commonPasswords is a small embedded deny-list of passwords that show
+// up at the top of every breach corpus. Matching is case-insensitive.
+var commonPasswords = map[string]bool{
+	"password":   true,
+	"password1":  true,
+	"passw0rd":   true,
+	"123456":     true,
+	"1234567":    true,
+	"12345678":   true,
+	"123456789":  true,
+	"1234567890": true,
+	"qwerty":     true,
+	"qwerty123":  true,
+	"qwertyuiop": true,
+	"111111":     true,
+	"letmein":    true,
+	"iloveyou":   true,
+	"admin":      true,
+	"welcome":    true,
+	"monkey":     true,
+	"dragon":     true,
+	"football":   true,
+	"baseball":   true,
+	"abc123":     true,
+	"sunshine":   true,
+	"princess":   true,
+	"trustno1":   true,
+	"gophkeeper": true,
+}
+
+// PasswordPolicy configures account-password strength requirements. The
+// zero value applies the defaults.
+type PasswordPolicy struct {
+	// MinLength is the minimum password length; zero or less means
+	// DefaultMinPasswordLength.
+	MinLength int
+}
+
+// Validate checks password against the policy and returns a message
+// suitable for showing to the user on failure.
+func (p PasswordPolicy) Validate(password string) error {
+	minLength := p.MinLength
+	if minLength <= 0 {
+		minLength = DefaultMinPasswordLength
+	}
+	if len(password) < minLength {
+		return fmt.Errorf("password must be at least %d characters", minLength)
+	}
+	if commonPasswords[strings.ToLower(password)] {
+		return fmt.Errorf("password is too common, pick something less guessable")
+	}
+	return nil
+}
+
+// ValidatePasswordStrength checks password against the default policy.
+func ValidatePasswordStrength(password string) error {
+	return PasswordPolicy{}.Validate(password)
+}
+
+// passwordAlphabet is the character set GeneratePassword draws from:
+// letters, digits and shell-safe punctuation.
+const passwordAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_.!@#%+="
+
+// DefaultGeneratedPasswordLength is used when GeneratePassword is
+// given a non-positive length.
+const DefaultGeneratedPasswordLength = 24
+
+// GeneratePassword returns a random password of the given length drawn
+// uniformly from passwordAlphabet using crypto/rand.
+func GeneratePassword(length int) (string, error) {
+	if length <= 0 {
+		length = DefaultGeneratedPasswordLength
+	}
+	out := make([]byte, length)
+	for i := range out {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordAlphabet))))
+		if err != nil {
+			return "", fmt.Errorf("generate password: %w", err)
+		}
+		out[i] = passwordAlphabet[n.Int64()]
+	}
+	return string(out), nil
+}
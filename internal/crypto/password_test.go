@@ -0,0 +1,71 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePasswordStrength(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{"too short", "a", true},
+		{"just under default minimum", "abcdefg", true},
+		{"common password", "password", true},
+		{"common password mixed case", "PaSsWoRd", true},
+		{"common password long enough", "sunshine", true},
+		{"acceptable", "correct-horse-battery", false},
+		{"acceptable minimum length", "xk29!mQz", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePasswordStrength(tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidatePasswordStrength(%q) = %v, wantErr %v", tt.password, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyMinLength(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 12}
+	if err := policy.Validate("short-pw"); err == nil {
+		t.Fatal("8-char password passed a 12-char policy")
+	}
+	if err := policy.Validate("long-enough-pw"); err != nil {
+		t.Fatalf("14-char password failed a 12-char policy: %v", err)
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	got, err := GeneratePassword(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 32 {
+		t.Fatalf("generated %d characters, want 32", len(got))
+	}
+	for _, r := range got {
+		if !strings.ContainsRune(passwordAlphabet, r) {
+			t.Fatalf("character %q outside the alphabet", r)
+		}
+	}
+
+	other, err := GeneratePassword(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == other {
+		t.Fatal("two generated passwords were identical")
+	}
+
+	short, err := GeneratePassword(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(short) != DefaultGeneratedPasswordLength {
+		t.Fatalf("default length is %d, want %d", len(short), DefaultGeneratedPasswordLength)
+	}
+}
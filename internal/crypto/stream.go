@@ -0,0 +1,171 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// VersionStreamAESGCM is format version 3: chunked streaming encryption
+// with Argon2id key derivation and AES-256-GCM, used for large binary
+// items that should not be held in memory at once.
+const VersionStreamAESGCM byte = 3
+
+// FrameSize is the plaintext size of every streaming frame except
+// possibly the last one.
+const FrameSize = 64 * 1024
+
+// Associated-data markers distinguishing intermediate frames from the
+// final one, so a truncated stream fails authentication.
+var (
+	adFrame      = []byte{0}
+	adFinalFrame = []byte{1}
+)
+
+// ErrTruncated is returned by DecryptStream when the input ends before
+// the final frame was seen.
+var ErrTruncated = fmt.Errorf("crypto: stream truncated")
+
+// IsStreamFormat reports whether data begins with the streaming format
+// header, so callers can pick between DecryptStream and
+// DecryptWithPassword.
+func IsStreamFormat(data []byte) bool {
+	return len(data) >= headerSize && data[0] == magic0 && data[1] == magic1 && data[2] == VersionStreamAESGCM
+}
+
+// EncryptStream encrypts r to w in fixed-size frames so arbitrarily
+// large inputs are processed with bounded memory. The output layout is:
+//
+//	magic (2) | version (1) | salt (16) | frame...
+//
+// where each frame is a 4-byte big-endian ciphertext length followed by
+// the ciphertext. Every frame is sealed with a nonce derived from a
+// monotonically increasing counter, and the final frame (which may be
+// empty) is bound to a distinct marker so truncation is detected.
+func EncryptStream(password string, r io.Reader, w io.Writer) error {
+	salt := make([]byte, SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("generate salt: %w", err)
+	}
+
+	key := DeriveKey(password, salt)
+	defer Zeroize(key)
+	aead, err := newAEAD(key, VersionAESGCM)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte{magic0, magic1, VersionStreamAESGCM}); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	if _, err := w.Write(salt); err != nil {
+		return fmt.Errorf("write salt: %w", err)
+	}
+
+	buf := make([]byte, FrameSize)
+	var counter uint64
+	var lenBuf [4]byte
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return fmt.Errorf("read plaintext: %w", readErr)
+		}
+		final := readErr != nil
+
+		ad := adFrame
+		if final {
+			ad = adFinalFrame
+		}
+		sealed := aead.Seal(nil, frameNonce(counter, aead.NonceSize()), buf[:n], ad)
+		counter++
+
+		binary.BigEndian.PutUint32(lenBuf[:], uint32(len(sealed)))
+		if _, err := w.Write(lenBuf[:]); err != nil {
+			return fmt.Errorf("write frame length: %w", err)
+		}
+		if _, err := w.Write(sealed); err != nil {
+			return fmt.Errorf("write frame: %w", err)
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// DecryptStream reverses EncryptStream, writing the recovered plaintext
+// to w. It returns ErrTruncated if the input ends before the final
+// frame, and an authentication error if any frame was tampered with or
+// reordered.
+func DecryptStream(password string, r io.Reader, w io.Writer) error {
+	header := make([]byte, headerSize+SaltSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return ErrInvalidFormat
+	}
+	if header[0] != magic0 || header[1] != magic1 {
+		return ErrInvalidFormat
+	}
+	if header[2] != VersionStreamAESGCM {
+		return fmt.Errorf("crypto: unsupported format version %d", header[2])
+	}
+	salt := header[headerSize:]
+
+	key := DeriveKey(password, salt)
+	defer Zeroize(key)
+	aead, err := newAEAD(key, VersionAESGCM)
+	if err != nil {
+		return err
+	}
+
+	maxFrame := FrameSize + aead.Overhead()
+	var counter uint64
+	var lenBuf [4]byte
+	for {
+		if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+			return ErrTruncated
+		}
+		frameLen := int(binary.BigEndian.Uint32(lenBuf[:]))
+		if frameLen < aead.Overhead() || frameLen > maxFrame {
+			return ErrInvalidFormat
+		}
+
+		sealed := make([]byte, frameLen)
+		if _, err := io.ReadFull(r, sealed); err != nil {
+			return ErrTruncated
+		}
+
+		nonce := frameNonce(counter, aead.NonceSize())
+		counter++
+
+		// A full-size frame can only be intermediate; anything shorter
+		// must be the final frame. Try the matching marker first and
+		// fall back to the other so a final frame that happens to be
+		// exactly FrameSize long still decrypts.
+		plaintext, openErr := aead.Open(nil, nonce, sealed, adFrame)
+		final := false
+		if openErr != nil {
+			plaintext, openErr = aead.Open(nil, nonce, sealed, adFinalFrame)
+			final = true
+		}
+		if openErr != nil {
+			return fmt.Errorf("decrypt frame %d: %w", counter-1, openErr)
+		}
+
+		if _, err := w.Write(plaintext); err != nil {
+			return fmt.Errorf("write plaintext: %w", err)
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// frameNonce builds a deterministic per-frame nonce from the frame
+// counter. Keys are unique per stream (fresh salt), so counter-based
+// nonces never repeat under the same key.
+func frameNonce(counter uint64, size int) []byte {
+	nonce := make([]byte, size)
+	binary.BigEndian.PutUint64(nonce[size-8:], counter)
+	return nonce
+}
@@ -0,0 +1,72 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func streamRoundTrip(t *testing.T, plaintext []byte) []byte {
+	t.Helper()
+
+	var encrypted bytes.Buffer
+	if err := EncryptStream("master-password", bytes.NewReader(plaintext), &encrypted); err != nil {
+		t.Fatalf("encrypt stream: %v", err)
+	}
+
+	var decrypted bytes.Buffer
+	if err := DecryptStream("master-password", bytes.NewReader(encrypted.Bytes()), &decrypted); err != nil {
+		t.Fatalf("decrypt stream: %v", err)
+	}
+	if !bytes.Equal(decrypted.Bytes(), plaintext) {
+		t.Fatalf("round trip mismatch: got %d bytes, want %d bytes", decrypted.Len(), len(plaintext))
+	}
+	return encrypted.Bytes()
+}
+
+func TestStreamEmptyInput(t *testing.T) {
+	streamRoundTrip(t, nil)
+}
+
+func TestStreamSmallerThanFrame(t *testing.T) {
+	streamRoundTrip(t, []byte("short binary payload"))
+}
+
+func TestStreamMultiFrame(t *testing.T) {
+	plaintext := make([]byte, 3*FrameSize+123)
+	if _, err := rand.Read(plaintext); err != nil {
+		t.Fatal(err)
+	}
+	streamRoundTrip(t, plaintext)
+}
+
+func TestStreamExactFrameMultiple(t *testing.T) {
+	plaintext := make([]byte, 2*FrameSize)
+	if _, err := rand.Read(plaintext); err != nil {
+		t.Fatal(err)
+	}
+	streamRoundTrip(t, plaintext)
+}
+
+func TestStreamTruncationDetected(t *testing.T) {
+	plaintext := make([]byte, 2*FrameSize)
+	encrypted := streamRoundTrip(t, plaintext)
+
+	// Drop the final frame entirely: the stream still starts with valid
+	// frames but must fail instead of silently returning partial data.
+	truncated := encrypted[:headerSize+SaltSize+4+FrameSize+16]
+	var out bytes.Buffer
+	if err := DecryptStream("master-password", bytes.NewReader(truncated), &out); err == nil {
+		t.Fatal("expected truncated stream to fail")
+	}
+}
+
+func TestStreamTamperDetected(t *testing.T) {
+	encrypted := streamRoundTrip(t, []byte("payload"))
+	encrypted[len(encrypted)-1] ^= 0xFF
+
+	var out bytes.Buffer
+	if err := DecryptStream("master-password", bytes.NewReader(encrypted), &out); err == nil {
+		t.Fatal("expected tampered stream to fail")
+	}
+}
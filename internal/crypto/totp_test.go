@@ -0,0 +1,83 @@
+package crypto
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B test secrets: the ASCII seed repeated to the
+// hash's natural key length, base32-encoded.
+var totpTestSecrets = map[string]string{
+	"SHA1":   base32.StdEncoding.EncodeToString([]byte("12345678901234567890")),
+	"SHA256": base32.StdEncoding.EncodeToString([]byte("12345678901234567890123456789012")),
+	"SHA512": base32.StdEncoding.EncodeToString([]byte("1234567890123456789012345678901234567890123456789012345678901234")),
+}
+
+func TestGenerateTOTPVectors(t *testing.T) {
+	// Test vectors from RFC 6238 appendix B (8-digit codes).
+	vectors := []struct {
+		unix      int64
+		algorithm string
+		want      string
+	}{
+		{59, "SHA1", "94287082"},
+		{59, "SHA256", "46119246"},
+		{59, "SHA512", "90693936"},
+		{1111111109, "SHA1", "07081804"},
+		{1234567890, "SHA1", "89005924"},
+		{2000000000, "SHA256", "90698825"},
+		{20000000000, "SHA512", "47863826"},
+	}
+	for _, v := range vectors {
+		got, err := GenerateTOTP(totpTestSecrets[v.algorithm], v.algorithm, 8, 30, time.Unix(v.unix, 0))
+		if err != nil {
+			t.Fatalf("t=%d %s: %v", v.unix, v.algorithm, err)
+		}
+		if got != v.want {
+			t.Errorf("t=%d %s: got %s, want %s", v.unix, v.algorithm, got, v.want)
+		}
+	}
+}
+
+func TestGenerateTOTPDefaults(t *testing.T) {
+	// Empty algorithm and zero digits/period mean SHA1, 6 digits, 30s.
+	got, err := GenerateTOTP(totpTestSecrets["SHA1"], "", 0, 0, time.Unix(59, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "287082" {
+		t.Fatalf("got %s, want 287082", got)
+	}
+}
+
+func TestDecodeTOTPSecretForgiving(t *testing.T) {
+	padded := totpTestSecrets["SHA1"]
+	variants := []string{
+		padded,
+		"gezd gnbv gy3t qojq gezd gnbv gy3t qojq",
+		"GEZD-GNBV-GY3T-QOJQ-GEZD-GNBV-GY3T-QOJQ",
+	}
+	for _, secret := range variants {
+		got, err := GenerateTOTP(secret, "SHA1", 8, 30, time.Unix(59, 0))
+		if err != nil {
+			t.Fatalf("%q: %v", secret, err)
+		}
+		if got != "94287082" {
+			t.Errorf("%q: got %s, want 94287082", secret, got)
+		}
+	}
+
+	if _, err := GenerateTOTP("not base32!", "SHA1", 6, 30, time.Unix(59, 0)); err == nil {
+		t.Fatal("invalid secret accepted")
+	}
+}
+
+func TestTOTPRemaining(t *testing.T) {
+	if got := TOTPRemaining(30, time.Unix(59, 0)); got != 1 {
+		t.Fatalf("at t=59 got %d seconds remaining, want 1", got)
+	}
+	if got := TOTPRemaining(0, time.Unix(60, 0)); got != 30 {
+		t.Fatalf("at a period boundary got %d, want 30", got)
+	}
+}
@@ -0,0 +1,15 @@
+package crypto
+
+// Zeroize overwrites b with zeros. It is used to wipe derived keys and
+// other secrets once they are no longer needed.
+//
+// Limitations: Go gives no hard guarantees here. The garbage collector
+// may have copied the slice's backing array during its lifetime, and
+// secrets that ever lived in a string cannot be wiped at all, so
+// callers should keep secrets in []byte form. Zeroize is best-effort
+// hygiene, not a substitute for OS-level protections.
+func Zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,18 @@
+package crypto
+
+import "testing"
+
+func TestZeroize(t *testing.T) {
+	b := []byte{1, 2, 3, 4, 5}
+	Zeroize(b)
+	for i, v := range b {
+		if v != 0 {
+			t.Fatalf("byte %d not wiped: %d", i, v)
+		}
+	}
+}
+
+func TestZeroizeEmpty(t *testing.T) {
+	Zeroize(nil)
+	Zeroize([]byte{})
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CardData is the decrypted payload of a TypeCard item: a payment card
+// number with its holder, expiry and security code.
+type CardData struct {
+	Number      string `json:"number"`
+	Holder      string `json:"holder,omitempty"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	CVV         string `json:"cvv,omitempty"`
+}
+
+// Validate checks the card fields before they are stored, so typos are
+// caught while the user can still fix them. Each error names the field
+// that failed.
+func (c CardData) Validate() error {
+	digits := cardDigits(c.Number)
+	if len(digits) < 12 || len(digits) > 19 {
+		return errors.New("card number: must be 12 to 19 digits")
+	}
+	if !luhnValid(digits) {
+		return errors.New("card number: failed the Luhn check, check for typos")
+	}
+	if c.ExpiryMonth < 1 || c.ExpiryMonth > 12 {
+		return errors.New("expiry month: must be between 01 and 12")
+	}
+	year := c.ExpiryYear
+	if year < 100 {
+		// Two-digit years are how cards print them.
+		year += 2000
+	}
+	// A card is valid through the last moment of its expiry month.
+	if time.Date(year, time.Month(c.ExpiryMonth)+1, 1, 0, 0, 0, 0, time.UTC).Before(time.Now()) {
+		return fmt.Errorf("expiry: card expired %02d/%02d", c.ExpiryMonth, year%100)
+	}
+	if n := len(c.CVV); n < 3 || n > 4 || strings.Trim(c.CVV, "0123456789") != "" {
+		return errors.New("cvv: must be 3 or 4 digits")
+	}
+	return nil
+}
+
+// Brand returns the card network implied by the number's leading
+// digits (the issuer identification number), or the empty string for
+// an unrecognized prefix.
+func (c CardData) Brand() string {
+	d := cardDigits(c.Number)
+	switch {
+	case strings.HasPrefix(d, "34") || strings.HasPrefix(d, "37"):
+		return "Amex"
+	case strings.HasPrefix(d, "4"):
+		return "Visa"
+	case inPrefixRange(d, 51, 55) || inPrefixRange(d, 2221, 2720):
+		return "Mastercard"
+	case strings.HasPrefix(d, "6011") || strings.HasPrefix(d, "65") || inPrefixRange(d, 644, 649):
+		return "Discover"
+	}
+	return ""
+}
+
+// Masked returns the number with all but the last four digits hidden,
+// grouped the way the brand prints them: 4-6-5 for a 15-digit Amex,
+// groups of four for everyone else.
+func (c CardData) Masked() string {
+	d := cardDigits(c.Number)
+	if len(d) <= 4 {
+		return d
+	}
+	masked := strings.Repeat("*", len(d)-4) + d[len(d)-4:]
+	if c.Brand() == "Amex" && len(masked) == 15 {
+		return masked[:4] + " " + masked[4:10] + " " + masked[10:]
+	}
+	var groups []string
+	for len(masked) > 4 {
+		groups = append(groups, masked[:4])
+		masked = masked[4:]
+	}
+	return strings.Join(append(groups, masked), " ")
+}
+
+// inPrefixRange reports whether the number's leading digits, read with
+// the same width as lo and hi, fall inside [lo, hi].
+func inPrefixRange(digits string, lo, hi int) bool {
+	width := len(strconv.Itoa(lo))
+	if len(digits) < width {
+		return false
+	}
+	prefix, err := strconv.Atoi(digits[:width])
+	return err == nil && prefix >= lo && prefix <= hi
+}
+
+// cardDigits strips the spaces and dashes people type between digit
+// groups. Any other character makes the number invalid, so it is kept
+// and fails the length or Luhn check.
+func cardDigits(number string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, number)
+}
+
+// luhnValid reports whether digits passes the Luhn checksum every
+// payment card number carries.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '0' || digits[i] > '9' {
+			return false
+		}
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
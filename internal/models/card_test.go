@@ -0,0 +1,88 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCardValidate(t *testing.T) {
+	future := time.Now().AddDate(1, 0, 0)
+	valid := CardData{
+		Number:      "4111 1111 1111 1111",
+		Holder:      "J DOE",
+		ExpiryMonth: int(future.Month()),
+		ExpiryYear:  future.Year() % 100,
+		CVV:         "123",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid card rejected: %v", err)
+	}
+
+	fourDigitCVV := valid
+	fourDigitCVV.Number = "3782 822463 10005"
+	fourDigitCVV.CVV = "1234"
+	if err := fourDigitCVV.Validate(); err != nil {
+		t.Fatalf("valid Amex rejected: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(*CardData)
+		want   string
+	}{
+		{"bad luhn", func(c *CardData) { c.Number = "4111 1111 1111 1112" }, "Luhn"},
+		{"too short", func(c *CardData) { c.Number = "4111 1111" }, "12 to 19"},
+		{"letters", func(c *CardData) { c.Number = "4111 1111 1111 111a" }, "Luhn"},
+		{"month zero", func(c *CardData) { c.ExpiryMonth = 0 }, "expiry month"},
+		{"month thirteen", func(c *CardData) { c.ExpiryMonth = 13 }, "expiry month"},
+		{"expired", func(c *CardData) { c.ExpiryMonth = 1; c.ExpiryYear = 20 }, "expired"},
+		{"cvv short", func(c *CardData) { c.CVV = "12" }, "cvv"},
+		{"cvv letters", func(c *CardData) { c.CVV = "12a" }, "cvv"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			card := valid
+			tc.mutate(&card)
+			err := card.Validate()
+			if err == nil {
+				t.Fatalf("invalid card accepted: %+v", card)
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("error %q does not mention %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCardBrand(t *testing.T) {
+	cases := []struct {
+		number string
+		want   string
+	}{
+		{"4111 1111 1111 1111", "Visa"},
+		{"5500 0000 0000 0004", "Mastercard"},
+		{"2221 0000 0000 0009", "Mastercard"},
+		{"3782 822463 10005", "Amex"},
+		{"6011 0000 0000 0004", "Discover"},
+		{"6500 0000 0000 0002", "Discover"},
+		{"9999 0000 0000 0001", ""},
+	}
+	for _, tc := range cases {
+		card := CardData{Number: tc.number}
+		if got := card.Brand(); got != tc.want {
+			t.Errorf("Brand(%q) = %q, want %q", tc.number, got, tc.want)
+		}
+	}
+}
+
+func TestCardMasked(t *testing.T) {
+	visa := CardData{Number: "4111111111111111"}
+	if got := visa.Masked(); got != "**** **** **** 1111" {
+		t.Errorf("Visa mask = %q", got)
+	}
+	amex := CardData{Number: "378282246310005"}
+	if got := amex.Masked(); got != "**** ****** *0005" {
+		t.Errorf("Amex mask = %q", got)
+	}
+}
@@ -0,0 +1,179 @@
+// Package models defines the core data types shared by the storage
+// layer, the server and the client.
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Supported item types. The type tells the client how to interpret the
+// decrypted payload; the server treats all payloads as opaque bytes.
+const (
+	TypeLoginPassword = "login_password"
+	TypeText          = "text"
+	TypeBinary        = "binary"
+	TypeCard          = "card"
+	TypeTOTP          = "totp"
+	TypeSSHKey        = "ssh_key"
+)
+
+// Stale reports whether the item's last update is more than maxAge
+// before now, for rotation-hygiene reminders. A non-positive maxAge or
+// an unset UpdatedAt never counts as stale.
+func (i *Item) Stale(maxAge time.Duration, now time.Time) bool {
+	if maxAge <= 0 || i.UpdatedAt.IsZero() {
+		return false
+	}
+	return now.Sub(i.UpdatedAt) > maxAge
+}
+
+// KnownType reports whether t is one of the item type constants above.
+func KnownType(t string) bool {
+	switch t {
+	case TypeLoginPassword, TypeText, TypeBinary, TypeCard, TypeTOTP, TypeSSHKey:
+		return true
+	}
+	return false
+}
+
+// SSHKeyData is the decrypted payload of a TypeSSHKey item: a private
+// key in PEM form together with its public half and optional passphrase.
+type SSHKeyData struct {
+	Name       string `json:"name,omitempty"`
+	PrivateKey string `json:"private_key"`
+	PublicKey  string `json:"public_key,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// TOTPData is the decrypted payload of a TypeTOTP item: a 2FA seed plus
+// the parameters needed to compute codes from it. Zero values for
+// Algorithm, Digits and Period mean the RFC 6238 defaults (SHA1, 6, 30).
+type TOTPData struct {
+	Issuer    string `json:"issuer,omitempty"`
+	Account   string `json:"account,omitempty"`
+	Secret    string `json:"secret"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Digits    int    `json:"digits,omitempty"`
+	Period    int    `json:"period,omitempty"`
+}
+
+// Item is a single stored secret. Data holds the client-side encrypted
+// payload; the server never sees plaintext.
+type Item struct {
+	ID       int64
+	UserID   int64
+	Name     string
+	Type     string
+	Data     []byte
+	Metadata map[string]string
+	Tags     []string
+	Version  int64
+	// ExpiresAt marks when the item self-destructs; the zero value
+	// means it never expires.
+	ExpiresAt time.Time
+	// DeletedAt marks a soft-deleted item awaiting purge; the zero
+	// value means the item is live.
+	DeletedAt time.Time
+	// Notes is a free-text note carried inside the encrypted payload.
+	// The client fills it after decryption; it is never stored or sent
+	// in plaintext and the server does not see it.
+	Notes     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Limits enforced by Item.Validate. Payloads are opaque to the server,
+// so these bound only the plaintext fields the user types in directly:
+// names and metadata have to stay usable in listings and filters.
+const (
+	MaxNameLength          = 255
+	MaxMetadataKeyLength   = 255
+	MaxMetadataValueLength = 1024
+	MaxTagLength           = 64
+)
+
+// Validate checks the user-supplied plaintext fields before the item is
+// sent anywhere, so typos fail fast with a clear message instead of a
+// server round trip.
+func (i *Item) Validate() error {
+	if strings.TrimSpace(i.Name) == "" {
+		return errors.New("item name must not be blank")
+	}
+	if len(i.Name) > MaxNameLength {
+		return fmt.Errorf("item name is %d characters, the maximum is %d", len(i.Name), MaxNameLength)
+	}
+	for key, value := range i.Metadata {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("metadata keys must not be blank")
+		}
+		if len(key) > MaxMetadataKeyLength {
+			return fmt.Errorf("metadata key %q is %d characters, the maximum is %d", key, len(key), MaxMetadataKeyLength)
+		}
+		if len(value) > MaxMetadataValueLength {
+			return fmt.Errorf("metadata value for %q is %d characters, the maximum is %d", key, len(value), MaxMetadataValueLength)
+		}
+	}
+	for _, tag := range i.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return errors.New("tags must not be blank")
+		}
+		if len(tag) > MaxTagLength {
+			return fmt.Errorf("tag %q is %d characters, the maximum is %d", tag, len(tag), MaxTagLength)
+		}
+	}
+	return nil
+}
+
+// ItemVersion is an archived prior value of an item, written whenever
+// the item is updated so earlier ciphertexts can be restored.
+type ItemVersion struct {
+	ID         int64
+	ItemID     int64
+	UserID     int64
+	Version    int64
+	Data       []byte
+	Metadata   map[string]string
+	Tags       []string
+	ArchivedAt time.Time
+}
+
+// AuditEntry is one append-only record of a data-access event: who did
+// what to which item, when and from where. Item contents are never
+// recorded.
+type AuditEntry struct {
+	ID     int64
+	UserID int64
+	// Action is one of add, get, update, delete or list.
+	Action string
+	// ItemID is zero for actions that touch no single item.
+	ItemID    int64
+	IP        string
+	CreatedAt time.Time
+}
+
+// RefreshToken is a server-side record of an issued refresh token, so
+// tokens can be revoked and rotated.
+type RefreshToken struct {
+	Token     string
+	UserID    int64
+	ExpiresAt time.Time
+}
+
+// User is a registered account. PasswordHash is the bcrypt hash of the
+// account password (not the master password, which never leaves the
+// client).
+type User struct {
+	ID           int64
+	Login        string
+	PasswordHash string
+	CreatedAt    time.Time
+	// LastLoginAt and LastLoginIP record the most recent successful
+	// login, so users can spot access they do not recognize. Both are
+	// zero until the first login.
+	LastLoginAt time.Time
+	LastLoginIP string
+}
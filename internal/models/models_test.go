@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateAcceptsTypicalItem(t *testing.T) {
+	item := &Item{
+		Name:     "mail",
+		Type:     TypeLoginPassword,
+		Metadata: map[string]string{"site": "example.com"},
+		Tags:     []string{"work"},
+	}
+	if err := item.Validate(); err != nil {
+		t.Fatalf("valid item rejected: %v", err)
+	}
+}
+
+func TestValidateRejections(t *testing.T) {
+	tests := []struct {
+		name string
+		item Item
+	}{
+		{"blank name", Item{Name: ""}},
+		{"whitespace name", Item{Name: "   "}},
+		{"overlong name", Item{Name: strings.Repeat("a", MaxNameLength+1)}},
+		{"blank metadata key", Item{Name: "ok", Metadata: map[string]string{" ": "x"}}},
+		{"overlong metadata key", Item{Name: "ok", Metadata: map[string]string{strings.Repeat("k", MaxMetadataKeyLength+1): "x"}}},
+		{"overlong metadata value", Item{Name: "ok", Metadata: map[string]string{"k": strings.Repeat("v", MaxMetadataValueLength+1)}}},
+		{"blank tag", Item{Name: "ok", Tags: []string{" "}}},
+		{"overlong tag", Item{Name: "ok", Tags: []string{strings.Repeat("t", MaxTagLength+1)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.item.Validate(); err == nil {
+				t.Fatalf("%s was accepted", tt.name)
+			}
+		})
+	}
+}
+
+func TestStaleFlagsOldItems(t *testing.T) {
+	now := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+	threshold := 90 * 24 * time.Hour
+	tests := []struct {
+		name    string
+		updated time.Time
+		want    bool
+	}{
+		{"updated yesterday", now.Add(-24 * time.Hour), false},
+		{"just inside the threshold", now.Add(-threshold + time.Hour), false},
+		{"just past the threshold", now.Add(-threshold - time.Hour), true},
+		{"ancient", now.AddDate(-2, 0, 0), true},
+		{"never synced", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &Item{Name: "x", UpdatedAt: tt.updated}
+			if got := item.Stale(threshold, now); got != tt.want {
+				t.Fatalf("Stale = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A disabled threshold never flags anything.
+	old := &Item{Name: "x", UpdatedAt: now.AddDate(-2, 0, 0)}
+	if old.Stale(0, now) {
+		t.Fatal("zero threshold flagged an item")
+	}
+}
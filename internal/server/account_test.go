@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	"github.com/ar11/gophkeeper/internal/storage"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestDeleteAccountRemovesUserAndItems(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	srv := New(store, testSecret)
+
+	resp, err := srv.Register(context.Background(), &pb.RegisterRequest{
+		Login: "doomed", Password: "long-enough-pw",
+	})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	user, err := store.GetUserByLogin(context.Background(), "doomed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddItem(context.Background(), &models.Item{
+		UserID: user.ID, Name: "secret", Type: models.TypeText, Data: []byte("x"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := srv.tokens.ParseClaims(resp.GetToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := contextWithClaims(context.Background(), claims)
+
+	// A wrong password is rejected and deletes nothing.
+	_, err = srv.DeleteAccount(ctx, &pb.DeleteAccountRequest{Password: "not-the-password"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("wrong password = %v, want Unauthenticated", err)
+	}
+	if _, err := store.GetUserByLogin(context.Background(), "doomed"); err != nil {
+		t.Fatalf("user gone after failed deletion: %v", err)
+	}
+
+	if _, err := srv.DeleteAccount(ctx, &pb.DeleteAccountRequest{Password: "long-enough-pw"}); err != nil {
+		t.Fatalf("delete account: %v", err)
+	}
+	if _, err := store.GetUserByLogin(context.Background(), "doomed"); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Fatalf("user lookup = %v, want ErrUserNotFound", err)
+	}
+	if _, err := store.GetItemByName(context.Background(), user.ID, "secret"); !errors.Is(err, storage.ErrItemNotFound) {
+		t.Fatalf("item lookup = %v, want ErrItemNotFound", err)
+	}
+
+	// The token that performed the deletion no longer authenticates.
+	md := metadata.Pairs("authorization", "Bearer "+resp.GetToken())
+	if _, _, err := invokeInterceptorOn(t, srv, "/gophkeeper.GophKeeper/GetItem", md); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("revoked token = %v, want Unauthenticated", err)
+	}
+}
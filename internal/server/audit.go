@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// defaultAuditPageSize caps GetAuditLog responses when the client does
+// not ask for a specific limit.
+const defaultAuditPageSize = 100
+
+// Audit actions, one per kind of data access. Entries never include
+// item contents, only which item was touched and how.
+const (
+	auditActionAdd     = "add"
+	auditActionGet     = "get"
+	auditActionUpdate  = "update"
+	auditActionDelete  = "delete"
+	auditActionList    = "list"
+	auditActionRestore = "restore"
+)
+
+// audit appends one entry to the user's access trail. A failed write is
+// logged but does not fail the request: the data operation has already
+// happened, and refusing the response would not undo it.
+func (s *Server) audit(ctx context.Context, userID int64, action string, itemID int64) {
+	entry := &models.AuditEntry{UserID: userID, Action: action, ItemID: itemID, IP: peerIP(ctx)}
+	if err := s.store.AppendAuditEntry(ctx, entry); err != nil {
+		s.log(ctx).Warn("audit write failed", "user_id", userID, "action", action, "error", err)
+	}
+}
+
+// peerIP returns the caller's address without the port, or the empty
+// string when the peer is unknown (e.g. in-process tests).
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// GetAuditLog returns the caller's own access history, newest first.
+// Users can only ever see their own trail; there is no cross-user view.
+func (s *Server) GetAuditLog(ctx context.Context, req *pb.GetAuditLogRequest) (*pb.GetAuditLogResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.GetLimit()
+	if limit <= 0 || limit > defaultAuditPageSize {
+		limit = defaultAuditPageSize
+	}
+	entries, err := s.store.ListAuditEntries(ctx, userID, limit)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.GetAuditLogResponse{}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &pb.AuditEntry{
+			Id:        entry.ID,
+			Action:    entry.Action,
+			ItemId:    entry.ItemID,
+			Ip:        entry.IP,
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+		})
+	}
+	return resp, nil
+}
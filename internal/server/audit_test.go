@@ -0,0 +1,63 @@
+package server
+
+import (
+	"testing"
+
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestAuditLogRecordsAddAndGet(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+
+	added, err := srv.AddItem(ctx, &pb.AddItemRequest{
+		Item: &pb.Item{Name: "audited", Type: "text", Data: []byte("x")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.GetItem(ctx, &pb.GetItemRequest{Id: added.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := srv.GetAuditLog(ctx, &pb.GetAuditLogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.GetEntries()) != 2 {
+		t.Fatalf("got %d audit entries, want 2", len(log.GetEntries()))
+	}
+	// Newest first: the get, then the add.
+	if got := log.GetEntries()[0]; got.GetAction() != auditActionGet || got.GetItemId() != added.GetId() {
+		t.Fatalf("entry 0 = %q on item %d, want get on %d", got.GetAction(), got.GetItemId(), added.GetId())
+	}
+	if got := log.GetEntries()[1]; got.GetAction() != auditActionAdd || got.GetItemId() != added.GetId() {
+		t.Fatalf("entry 1 = %q on item %d, want add on %d", got.GetAction(), got.GetItemId(), added.GetId())
+	}
+	if log.GetEntries()[0].GetCreatedAt() == nil {
+		t.Fatal("audit entry missing timestamp")
+	}
+}
+
+func TestAuditLogScopedToOwner(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+	ctx := authedContext(userID)
+
+	if _, err := srv.AddItem(ctx, &pb.AddItemRequest{
+		Item: &pb.Item{Name: "mine", Type: "text", Data: []byte("x")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := store.CreateUser(authedContext(userID), "other-auditor", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := srv.GetAuditLog(authedContext(other.ID), &pb.GetAuditLogRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.GetEntries()) != 0 {
+		t.Fatalf("other user sees %d foreign audit entries, want 0", len(log.GetEntries()))
+	}
+}
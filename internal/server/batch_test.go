@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	"github.com/ar11/gophkeeper/internal/storage"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestBatchAddItems(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+	ctx := authedContext(userID)
+
+	resp, err := srv.BatchAddItems(ctx, &pb.BatchAddItemsRequest{Items: []*pb.Item{
+		{Name: "first", Type: models.TypeText, Data: []byte("1")},
+		{Name: "second", Type: models.TypeText, Data: []byte("2")},
+	}})
+	if err != nil {
+		t.Fatalf("batch add: %v", err)
+	}
+	if !resp.GetApplied() {
+		t.Fatalf("clean batch not applied: %+v", resp.GetResults())
+	}
+	for _, result := range resp.GetResults() {
+		if result.GetError() != "" || result.GetId() == 0 {
+			t.Fatalf("bad result for %q: %+v", result.GetName(), result)
+		}
+		if _, err := store.GetItem(context.Background(), userID, result.GetId()); err != nil {
+			t.Fatalf("item %q not stored: %v", result.GetName(), err)
+		}
+	}
+}
+
+func TestBatchAddItemsRollsBackOnConflict(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+	ctx := authedContext(userID)
+
+	if _, err := store.AddItem(context.Background(), &models.Item{
+		UserID: userID, Name: "taken", Type: models.TypeText, Data: []byte("x"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := srv.BatchAddItems(ctx, &pb.BatchAddItemsRequest{Items: []*pb.Item{
+		{Name: "fresh", Type: models.TypeText, Data: []byte("1")},
+		{Name: "taken", Type: models.TypeText, Data: []byte("2")},
+		{Name: "fresh", Type: models.TypeText, Data: []byte("3")},
+	}})
+	if err != nil {
+		t.Fatalf("batch add: %v", err)
+	}
+	if resp.GetApplied() {
+		t.Fatal("conflicting batch reported as applied")
+	}
+	results := resp.GetResults()
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	if results[0].GetError() != "" {
+		t.Fatalf("valid item blamed: %+v", results[0])
+	}
+	if results[1].GetError() == "" || results[2].GetError() == "" {
+		t.Fatalf("conflicts not reported: %+v", results)
+	}
+	// Nothing from the failed batch may have been stored.
+	if _, err := store.GetItemByName(context.Background(), userID, "fresh"); !errors.Is(err, storage.ErrItemNotFound) {
+		t.Fatalf("failed batch left items behind: %v", err)
+	}
+}
+
+func TestBatchGetItemsAllFound(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+	ctx := authedContext(userID)
+
+	for _, name := range []string{"first", "second"} {
+		if _, err := store.AddItem(context.Background(), &models.Item{
+			UserID: userID, Name: name, Type: models.TypeText, Data: []byte(name),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := srv.BatchGetItems(ctx, &pb.BatchGetItemsRequest{Names: []string{"first", "second"}})
+	if err != nil {
+		t.Fatalf("batch get: %v", err)
+	}
+	results := resp.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for i, name := range []string{"first", "second"} {
+		r := results[i]
+		if r.GetName() != name || r.GetError() != "" {
+			t.Fatalf("bad result for %q: %+v", name, r)
+		}
+		if string(r.GetItem().GetData()) != name {
+			t.Fatalf("wrong data for %q: %q", name, r.GetItem().GetData())
+		}
+	}
+}
+
+func TestBatchGetItemsReportsMissingPerName(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+	ctx := authedContext(userID)
+
+	if _, err := store.AddItem(context.Background(), &models.Item{
+		UserID: userID, Name: "present", Type: models.TypeText, Data: []byte("x"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := srv.BatchGetItems(ctx, &pb.BatchGetItemsRequest{Names: []string{"present", "absent"}})
+	if err != nil {
+		t.Fatalf("batch get: %v", err)
+	}
+	results := resp.GetResults()
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].GetError() != "" || results[0].GetItem() == nil {
+		t.Fatalf("present item not returned: %+v", results[0])
+	}
+	if results[1].GetError() == "" || results[1].GetItem() != nil {
+		t.Fatalf("absent item not reported: %+v", results[1])
+	}
+}
+
+func TestBatchDeleteItems(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	for _, name := range []string{"one", "two", "keep"} {
+		if _, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: &pb.Item{
+			Name: name, Type: models.TypeText, Data: []byte("x"),
+		}}); err != nil {
+			t.Fatalf("add %q: %v", name, err)
+		}
+	}
+
+	resp, err := srv.BatchDeleteItems(ctx, &pb.BatchDeleteItemsRequest{
+		Names: []string{"one", "two", "missing"},
+	})
+	if err != nil {
+		t.Fatalf("batch delete: %v", err)
+	}
+	if resp.GetDeleted() != 2 {
+		t.Fatalf("deleted = %d, want 2", resp.GetDeleted())
+	}
+	if len(resp.GetResults()) != 3 {
+		t.Fatalf("got %d results, want 3", len(resp.GetResults()))
+	}
+	for i, want := range []string{"", "", "no item with this name"} {
+		if got := resp.GetResults()[i].GetError(); got != want {
+			t.Fatalf("result %d error = %q, want %q", i, got, want)
+		}
+	}
+
+	if _, err := store.GetItemByName(context.Background(), userID, "one"); !errors.Is(err, storage.ErrItemNotFound) {
+		t.Fatalf("deleted item still readable: %v", err)
+	}
+	list, err := srv.ListItems(ctx, &pb.ListItemsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.GetTotal() != 1 || list.GetItems()[0].GetName() != "keep" {
+		t.Fatalf("remaining items = %+v, want just keep", list.GetItems())
+	}
+}
+
+func TestBatchDeleteItemsRejectsEmptyBatch(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	if _, err := srv.BatchDeleteItems(authedContext(userID), &pb.BatchDeleteItemsRequest{}); err == nil {
+		t.Fatal("empty batch succeeded")
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/ar11/gophkeeper/pkg/auth"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestLoginUpgradesLowCostHash(t *testing.T) {
+	srv, store, _ := newTestServer(t)
+	srv.SetBcryptCost(bcrypt.MinCost + 2)
+	ctx := context.Background()
+
+	// Seed a user whose hash predates the cost bump.
+	oldHash, err := auth.HashPasswordCost("old-cost-pw-1", bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := store.CreateUser(ctx, "rehashed", oldHash)
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	if _, err := srv.Login(ctx, &pb.LoginRequest{Login: "rehashed", Password: "old-cost-pw-1"}); err != nil {
+		t.Fatalf("login with low-cost hash: %v", err)
+	}
+
+	upgraded, err := store.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upgraded.PasswordHash == oldHash {
+		t.Fatal("hash was not upgraded on login")
+	}
+	cost, err := bcrypt.Cost([]byte(upgraded.PasswordHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost != bcrypt.MinCost+2 {
+		t.Fatalf("upgraded hash cost = %d, want %d", cost, bcrypt.MinCost+2)
+	}
+	if !auth.CheckPassword(upgraded.PasswordHash, "old-cost-pw-1") {
+		t.Fatal("password no longer verifies against the upgraded hash")
+	}
+
+	// A second login finds the hash already at the configured cost and
+	// leaves it alone.
+	if _, err := srv.Login(ctx, &pb.LoginRequest{Login: "rehashed", Password: "old-cost-pw-1"}); err != nil {
+		t.Fatalf("login after upgrade: %v", err)
+	}
+	again, err := store.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.PasswordHash != upgraded.PasswordHash {
+		t.Fatal("hash rewritten even though the cost already matched")
+	}
+}
+
+func TestLoginLeavesHashAloneByDefault(t *testing.T) {
+	srv, store, _ := newTestServer(t)
+	ctx := context.Background()
+
+	hash, err := auth.HashPasswordCost("default-cost-pw-1", bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := store.CreateUser(ctx, "untouched", hash)
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if _, err := srv.Login(ctx, &pb.LoginRequest{Login: "untouched", Password: "default-cost-pw-1"}); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	got, err := store.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PasswordHash != hash {
+		t.Fatal("hash rewritten without a configured cost")
+	}
+}
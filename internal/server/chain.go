@@ -0,0 +1,22 @@
+package server
+
+import "google.golang.org/grpc"
+
+// UnaryInterceptors returns the server's full unary interceptor stack
+// in its canonical order: recovery outermost, so a panic anywhere in
+// the chain (not just the handler) becomes codes.Internal, then
+// logging, metrics, auth and rate limiting. The rate limiter sits
+// after auth on purpose — it keys authenticated calls by user ID. A
+// nil metrics skips that layer.
+//
+// Pass the result to grpc.ChainUnaryInterceptor.
+func (s *Server) UnaryInterceptors(m *Metrics) []grpc.UnaryServerInterceptor {
+	chain := []grpc.UnaryServerInterceptor{
+		s.RecoveryInterceptor(),
+		s.LoggingInterceptor(),
+	}
+	if m != nil {
+		chain = append(chain, m.UnaryInterceptor())
+	}
+	return append(chain, s.AuthInterceptor(), s.RateLimitInterceptor())
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+)
+
+func TestUnaryInterceptorOrder(t *testing.T) {
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	chain := srv.UnaryInterceptors(NewMetrics())
+
+	names := []string{"recovery", "logging", "metrics", "auth", "ratelimit"}
+	if len(chain) != len(names) {
+		t.Fatalf("chain has %d interceptors, want %d", len(chain), len(names))
+	}
+
+	// Wrap each interceptor with a recorder, then compose the chain
+	// the same way grpc.ChainUnaryInterceptor does and run one call.
+	var order []string
+	handler := grpc.UnaryHandler(func(ctx context.Context, req any) (any, error) {
+		order = append(order, "handler")
+		return nil, nil
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Login"}
+	for i := len(chain) - 1; i >= 0; i-- {
+		interceptor, name, next := chain[i], names[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			order = append(order, name)
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	if _, err := handler(context.Background(), nil); err != nil {
+		t.Fatalf("chained call failed: %v", err)
+	}
+
+	want := append(append([]string{}, names...), "handler")
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("interceptors ran as %v, want %v", order, want)
+	}
+}
+
+func TestUnaryInterceptorsWithoutMetrics(t *testing.T) {
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	if got := len(srv.UnaryInterceptors(nil)); got != 4 {
+		t.Fatalf("chain has %d interceptors without metrics, want 4", got)
+	}
+}
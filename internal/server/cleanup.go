@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// defaultCleanupInterval is how often expired items are purged when the
+// operator does not configure an interval.
+const defaultCleanupInterval = time.Hour
+
+// defaultPurgeRetention is how long soft-deleted items are kept before
+// the reaper hard-deletes them, when the operator does not configure a
+// retention.
+const defaultPurgeRetention = 30 * 24 * time.Hour
+
+// StartExpiryCleanup launches a background goroutine that removes
+// expired items every interval until ctx is cancelled. Expired items
+// are already invisible to reads; the cleanup just reclaims the rows.
+func (s *Server) StartExpiryCleanup(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultCleanupInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := s.store.DeleteExpiredItems(ctx, time.Now())
+				if err != nil {
+					s.logger.Error("expiry cleanup failed", "error", err)
+					continue
+				}
+				if removed > 0 {
+					s.logger.Info("expiry cleanup removed expired items", "count", removed)
+				}
+			}
+		}
+	}()
+}
+
+// StartPurgeReaper launches a background goroutine that hard-deletes
+// items soft-deleted more than retention ago, every interval, until ctx
+// is cancelled. This is what eventually reclaims the tombstones left by
+// DeleteItem.
+func (s *Server) StartPurgeReaper(ctx context.Context, interval, retention time.Duration) {
+	if interval <= 0 {
+		interval = defaultCleanupInterval
+	}
+	if retention <= 0 {
+		retention = defaultPurgeRetention
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := s.store.PurgeDeleted(ctx, 0, time.Now().Add(-retention))
+				if err != nil {
+					s.logger.Error("purge reaper failed", "error", err)
+					continue
+				}
+				if removed > 0 {
+					s.logger.Info("purge reaper removed soft-deleted items", "count", removed)
+				}
+			}
+		}
+	}()
+}
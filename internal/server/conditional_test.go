@@ -0,0 +1,54 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestGetItemConditionalFetch(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	if _, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: &pb.Item{
+		Name: "poll-me", Type: models.TypeText, Data: []byte("blob v1"),
+	}}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	// Unconditional fetch serves the item as before.
+	resp, err := srv.GetItem(ctx, &pb.GetItemRequest{Name: "poll-me"})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if resp.GetNotModified() || resp.GetItem().GetVersion() != 1 {
+		t.Fatalf("unconditional fetch = %+v", resp)
+	}
+
+	// The client already has version 1, so nothing comes back.
+	resp, err = srv.GetItem(ctx, &pb.GetItemRequest{Name: "poll-me", IfVersion: 1})
+	if err != nil {
+		t.Fatalf("conditional get: %v", err)
+	}
+	if !resp.GetNotModified() || resp.GetItem() != nil {
+		t.Fatalf("unchanged item should be not_modified, got %+v", resp)
+	}
+
+	if _, err := srv.UpdateItem(ctx, &pb.UpdateItemRequest{Item: &pb.Item{
+		Name: "poll-me", Type: models.TypeText, Data: []byte("blob v2"),
+	}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	// Version moved past 1, so the full item comes back.
+	resp, err = srv.GetItem(ctx, &pb.GetItemRequest{Name: "poll-me", IfVersion: 1})
+	if err != nil {
+		t.Fatalf("conditional get after update: %v", err)
+	}
+	if resp.GetNotModified() || resp.GetItem().GetVersion() != 2 {
+		t.Fatalf("changed item should be served in full, got %+v", resp)
+	}
+	if string(resp.GetItem().GetData()) != "blob v2" {
+		t.Fatalf("data = %q, want the updated blob", resp.GetItem().GetData())
+	}
+}
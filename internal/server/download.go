@@ -0,0 +1,55 @@
+package server
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// downloadChunkSize is how much encrypted data travels in each streamed
+// chunk; small enough to stay well under any message size limit.
+const downloadChunkSize = 64 * 1024
+
+// DownloadItem streams one item to the client: first a chunk carrying
+// the metadata and total size, then the encrypted payload in pieces.
+func (s *Server) DownloadItem(req *pb.DownloadItemRequest, stream pb.GophKeeper_DownloadItemServer) error {
+	ctx := stream.Context()
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	var item *models.Item
+	if req.GetId() != 0 {
+		item, err = s.store.GetItem(ctx, userID, req.GetId())
+	} else {
+		item, err = s.store.GetItemByName(ctx, userID, req.GetName())
+	}
+	if err != nil {
+		return grpcError(err)
+	}
+	if !item.ExpiresAt.IsZero() && !item.ExpiresAt.After(time.Now()) {
+		return status.Error(codes.FailedPrecondition, "item expired")
+	}
+	s.audit(ctx, userID, auditActionGet, item.ID)
+
+	data := item.Data
+	meta := itemToProto(item)
+	meta.Data = nil
+	if err := stream.Send(&pb.DownloadItemChunk{Item: meta, TotalSize: int64(len(data))}); err != nil {
+		return err
+	}
+	for off := 0; off < len(data); off += downloadChunkSize {
+		end := off + downloadChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		if err := stream.Send(&pb.DownloadItemChunk{Data: data[off:end]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
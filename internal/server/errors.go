@@ -0,0 +1,36 @@
+package server
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+	"github.com/ar11/gophkeeper/pkg/auth"
+)
+
+// grpcError maps domain errors onto gRPC status codes so clients can
+// branch on the code instead of parsing messages. Errors that already
+// carry a status pass through unchanged; anything unrecognized becomes
+// Internal.
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, storage.ErrItemNotFound), errors.Is(err, storage.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrUserExists), errors.Is(err, storage.ErrItemExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, storage.ErrVersionConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, auth.ErrExpiredToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
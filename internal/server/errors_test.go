@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestErrorStatusCodes(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	srv := New(store, []byte("test-secret"))
+
+	register := func(login string) context.Context {
+		t.Helper()
+		if _, err := srv.Register(context.Background(), &pb.RegisterRequest{Login: login, Password: "long-enough-pw"}); err != nil {
+			t.Fatal(err)
+		}
+		user, err := store.GetUserByLogin(context.Background(), login)
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims, err := srv.tokens.ParseClaims(mustToken(t, srv, user.ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return contextWithClaims(context.Background(), claims)
+	}
+	ctx := register("codes-user")
+
+	t.Run("InvalidArgument", func(t *testing.T) {
+		_, err := srv.Register(context.Background(), &pb.RegisterRequest{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("empty register = %v, want InvalidArgument", err)
+		}
+		_, err = srv.Register(context.Background(), &pb.RegisterRequest{Login: "u", Password: "short"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("weak password = %v, want InvalidArgument", err)
+		}
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		_, err := srv.Register(context.Background(), &pb.RegisterRequest{Login: "codes-user", Password: "long-enough-pw"})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Fatalf("duplicate register = %v, want AlreadyExists", err)
+		}
+		item := &pb.Item{Name: "taken-name", Type: "text", Data: []byte("x")}
+		if _, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: item}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = srv.AddItem(ctx, &pb.AddItemRequest{Item: item})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Fatalf("duplicate add = %v, want AlreadyExists", err)
+		}
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		_, err := srv.Login(context.Background(), &pb.LoginRequest{Login: "codes-user", Password: "wrong-password"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("bad password = %v, want Unauthenticated", err)
+		}
+		_, err = srv.Refresh(context.Background(), &pb.RefreshRequest{RefreshToken: "bogus"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("bogus refresh token = %v, want Unauthenticated", err)
+		}
+		_, err = srv.GetItem(context.Background(), &pb.GetItemRequest{Name: "x"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("missing claims = %v, want Unauthenticated", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := srv.GetItem(ctx, &pb.GetItemRequest{Name: "no-such-item"})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("missing item = %v, want NotFound", err)
+		}
+		_, err = srv.DeleteItem(ctx, &pb.DeleteItemRequest{Name: "no-such-item"})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("deleting missing item = %v, want NotFound", err)
+		}
+	})
+
+	t.Run("FailedPrecondition", func(t *testing.T) {
+		added, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: &pb.Item{Name: "conflicted", Type: "text", Data: []byte("x")}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stale := &pb.Item{Id: added.GetId(), Name: "conflicted", Type: "text", Data: []byte("y"), Version: 99}
+		_, err = srv.UpdateItem(ctx, &pb.UpdateItemRequest{Item: stale})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("stale update = %v, want FailedPrecondition", err)
+		}
+
+		expired := &pb.Item{Name: "gone", Type: "text", Data: []byte("x"), ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour))}
+		if _, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: expired}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = srv.GetItem(ctx, &pb.GetItemRequest{Name: "gone"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expired item = %v, want FailedPrecondition", err)
+		}
+	})
+}
+
+// mustToken issues an access token for userID directly off the server's
+// token config.
+func mustToken(t *testing.T, srv *Server, userID int64) string {
+	t.Helper()
+	token, err := srv.tokens.GenerateToken(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+)
+
+// HealthServer implements the standard grpc.health.v1 service, plus a
+// plain HTTP handler for load balancers. It reports SERVING only while
+// the backing store answers pings.
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	store storage.Storage
+}
+
+// NewHealthServer returns a health checker backed by store.
+func NewHealthServer(store storage.Storage) *HealthServer {
+	return &HealthServer{store: store}
+}
+
+// Check reports the server's health based on storage reachability.
+func (h *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if err := h.store.Ping(ctx); err != nil {
+		return &grpc_health_v1.HealthCheckResponse{
+			Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		}, nil
+	}
+	return &grpc_health_v1.HealthCheckResponse{
+		Status: grpc_health_v1.HealthCheckResponse_SERVING,
+	}, nil
+}
+
+// Watch is not implemented; pollers should use Check.
+func (h *HealthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	return status.Error(codes.Unimplemented, "health watch is not supported")
+}
+
+// ServeHTTP answers /healthz-style probes: 200 while healthy, 503
+// otherwise.
+func (h *HealthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.Ping(r.Context()); err != nil {
+		http.Error(w, "storage unreachable", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+)
+
+// brokenStore fails pings while delegating everything else, simulating
+// a lost database connection.
+type brokenStore struct {
+	*storage.MemoryStorage
+	pingErr error
+}
+
+func (s *brokenStore) Ping(ctx context.Context) error { return s.pingErr }
+
+func TestHealthCheck(t *testing.T) {
+	store := &brokenStore{MemoryStorage: storage.NewMemoryStorage()}
+	health := NewHealthServer(store)
+
+	resp, err := health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("healthy store reported %v, want SERVING", resp.Status)
+	}
+
+	store.pingErr = errors.New("connection refused")
+	resp, err = health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("broken store reported %v, want NOT_SERVING", resp.Status)
+	}
+}
+
+func TestHealthHTTP(t *testing.T) {
+	store := &brokenStore{MemoryStorage: storage.NewMemoryStorage()}
+	health := NewHealthServer(store)
+
+	rec := httptest.NewRecorder()
+	health.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("healthy store answered %d, want 200", rec.Code)
+	}
+
+	store.pingErr = errors.New("connection refused")
+	rec = httptest.NewRecorder()
+	health.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("broken store answered %d, want 503", rec.Code)
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// ListItemVersions returns the archived prior values of one item,
+// newest first, resolved by ID or name.
+func (s *Server) ListItemVersions(ctx context.Context, req *pb.ListItemVersionsRequest) (*pb.ListItemVersionsResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := req.GetId()
+	if id == 0 {
+		item, err := s.store.GetItemByName(ctx, userID, req.GetName())
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		id = item.ID
+	}
+	versions, err := s.store.ListItemVersions(ctx, userID, id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListItemVersionsResponse{}
+	for _, v := range versions {
+		resp.Versions = append(resp.Versions, itemVersionToProto(v))
+	}
+	return resp, nil
+}
+
+func itemVersionToProto(v *models.ItemVersion) *pb.ItemVersion {
+	return &pb.ItemVersion{
+		Version:    v.Version,
+		Data:       v.Data,
+		Metadata:   v.Metadata,
+		Tags:       v.Tags,
+		ArchivedAt: timestamppb.New(v.ArchivedAt),
+	}
+}
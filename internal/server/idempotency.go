@@ -0,0 +1,70 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultIdempotencyTTL is how long a processed AddItem key is
+// remembered. Retries arrive within seconds; after the TTL a reused
+// key is treated as a fresh request.
+const defaultIdempotencyTTL = time.Hour
+
+// idempotencyCache remembers the outcome of AddItem calls keyed by
+// (user, client-supplied key), so a retried request returns the
+// originally created item's ID instead of inserting a duplicate.
+type idempotencyCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time // stubbed in tests
+	results map[idempotencyKey]idempotencyResult
+}
+
+type idempotencyKey struct {
+	userID int64
+	key    string
+}
+
+type idempotencyResult struct {
+	id   int64
+	when time.Time
+}
+
+func newIdempotencyCache(ttl time.Duration) *idempotencyCache {
+	return &idempotencyCache{
+		ttl:     ttl,
+		now:     time.Now,
+		results: map[idempotencyKey]idempotencyResult{},
+	}
+}
+
+// get returns the item ID recorded for the user's key, if the entry
+// has not expired.
+func (c *idempotencyCache) get(userID int64, key string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := idempotencyKey{userID: userID, key: key}
+	rec, ok := c.results[k]
+	if !ok {
+		return 0, false
+	}
+	if c.now().Sub(rec.when) > c.ttl {
+		delete(c.results, k)
+		return 0, false
+	}
+	return rec.id, true
+}
+
+// put records the item created for the user's key, sweeping expired
+// entries while it holds the lock so the map stays bounded.
+func (c *idempotencyCache) put(userID int64, key string, id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, rec := range c.results {
+		if now.Sub(rec.when) > c.ttl {
+			delete(c.results, k)
+		}
+	}
+	c.results[idempotencyKey{userID: userID, key: key}] = idempotencyResult{id: id, when: now}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestAddItemIdempotencyKey(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+
+	req := &pb.AddItemRequest{
+		Item:           &pb.Item{Name: "idem", Type: models.TypeText, Data: []byte("x")},
+		IdempotencyKey: "retry-1",
+	}
+	first, err := srv.AddItem(ctx, req)
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	replay, err := srv.AddItem(ctx, req)
+	if err != nil {
+		t.Fatalf("replayed add: %v", err)
+	}
+	if replay.GetId() != first.GetId() {
+		t.Fatalf("replay created id %d, want the original %d", replay.GetId(), first.GetId())
+	}
+
+	list, err := srv.ListItems(ctx, &pb.ListItemsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.GetTotal() != 1 {
+		t.Fatalf("replayed add stored %d items, want 1", list.GetTotal())
+	}
+
+	// A different key is a different logical request and hits the
+	// duplicate-name check like any other add.
+	req.IdempotencyKey = "retry-2"
+	if _, err := srv.AddItem(ctx, req); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("add with a fresh key = %v, want AlreadyExists", err)
+	}
+}
+
+func TestAddItemIdempotencyKeyExpires(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	now := time.Now()
+	srv.idempotency.now = func() time.Time { return now }
+
+	req := &pb.AddItemRequest{
+		Item:           &pb.Item{Name: "short-lived", Type: models.TypeText, Data: []byte("x")},
+		IdempotencyKey: "retry-1",
+	}
+	if _, err := srv.AddItem(ctx, req); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	// Past the TTL the key is forgotten, so the replay is treated as a
+	// fresh request and collides with the stored item.
+	now = now.Add(defaultIdempotencyTTL + time.Minute)
+	if _, err := srv.AddItem(ctx, req); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("add after TTL = %v, want AlreadyExists", err)
+	}
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/pkg/auth"
+)
+
+// contextKey is the private type for values this package stores in a
+// request context.
+type contextKey int
+
+const claimsKey contextKey = iota
+
+// authExemptMethods are RPCs that must work without a token.
+var authExemptMethods = map[string]bool{
+	"/gophkeeper.GophKeeper/Register": true,
+	"/gophkeeper.GophKeeper/Login":    true,
+	"/gophkeeper.GophKeeper/Refresh":  true,
+	"/grpc.health.v1.Health/Check":    true,
+	"/grpc.health.v1.Health/Watch":    true,
+}
+
+// AuthInterceptor returns a unary interceptor that validates the bearer
+// token from the "authorization" metadata header, rejects revoked
+// tokens, and injects the claims into the handler context. Register,
+// Login and Refresh pass through untouched.
+func (s *Server) AuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if authExemptMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		claims, err := s.claimsFromMetadata(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(contextWithClaims(ctx, claims), req)
+	}
+}
+
+// wrappedServerStream overrides the context of an embedded stream so
+// interceptors can inject values for the handler.
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedServerStream) Context() context.Context { return w.ctx }
+
+// AuthStreamInterceptor is the streaming counterpart of
+// AuthInterceptor, authenticating streamed RPCs the same way.
+func (s *Server) AuthStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if authExemptMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		claims, err := s.claimsFromMetadata(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: contextWithClaims(ss.Context(), claims)})
+	}
+}
+
+// claimsFromMetadata extracts and validates the bearer token from the
+// incoming metadata.
+func (s *Server) claimsFromMetadata(ctx context.Context) (*auth.Claims, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	token := strings.TrimPrefix(values[0], "Bearer ")
+	claims, err := s.tokens.ParseClaims(token)
+	if errors.Is(err, auth.ErrExpiredToken) {
+		// Distinguishable so clients know a refresh can help.
+		return nil, status.Error(codes.Unauthenticated, auth.ErrExpiredToken.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	revoked, err := s.store.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return claims, nil
+}
+
+// contextWithClaims records the authenticated token claims in ctx.
+func contextWithClaims(ctx context.Context, claims *auth.Claims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
+// claimsFromContext returns the claims the interceptor authenticated,
+// or an error if the handler was reached without them.
+func claimsFromContext(ctx context.Context) (*auth.Claims, error) {
+	claims, ok := ctx.Value(claimsKey).(*auth.Claims)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return claims, nil
+}
+
+// userIDFromContext returns the user the interceptor authenticated.
+func userIDFromContext(ctx context.Context) (int64, error) {
+	claims, err := claimsFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
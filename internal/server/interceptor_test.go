@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ar11/gophkeeper/pkg/auth"
+)
+
+// invokeInterceptor runs the auth interceptor for method with the given
+// incoming metadata and reports the user ID the handler saw.
+func invokeInterceptor(t *testing.T, method string, md metadata.MD) (int64, bool, error) {
+	srv, _, _ := newTestServer(t)
+	return invokeInterceptorOn(t, srv, method, md)
+}
+
+func invokeInterceptorOn(t *testing.T, srv *Server, method string, md metadata.MD) (int64, bool, error) {
+	t.Helper()
+	interceptor := srv.AuthInterceptor()
+	ctx := context.Background()
+	if md != nil {
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	var gotUserID int64
+	var handlerCalled bool
+	handler := func(ctx context.Context, req any) (any, error) {
+		handlerCalled = true
+		gotUserID, _ = userIDFromContext(ctx)
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	_, err := interceptor(ctx, nil, info, handler)
+	return gotUserID, handlerCalled, err
+}
+
+func TestAuthInterceptorValidToken(t *testing.T) {
+	token, err := auth.GenerateToken(testSecret, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := metadata.Pairs("authorization", "Bearer "+token)
+	userID, called, err := invokeInterceptor(t, "/gophkeeper.GophKeeper/GetItem", md)
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if !called {
+		t.Fatal("handler not called")
+	}
+	if userID != 42 {
+		t.Fatalf("handler saw user %d, want 42", userID)
+	}
+}
+
+func TestAuthInterceptorMissingToken(t *testing.T) {
+	for name, md := range map[string]metadata.MD{
+		"no metadata": nil,
+		"no header":   metadata.Pairs("other", "value"),
+	} {
+		_, called, err := invokeInterceptor(t, "/gophkeeper.GophKeeper/GetItem", md)
+		if err == nil {
+			t.Errorf("%s: request without a token passed", name)
+		}
+		if called {
+			t.Errorf("%s: handler reached without auth", name)
+		}
+	}
+}
+
+func TestAuthInterceptorExpiredToken(t *testing.T) {
+	claims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-2 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		},
+		UserID: 42,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := metadata.Pairs("authorization", "Bearer "+token)
+	_, called, err := invokeInterceptor(t, "/gophkeeper.GophKeeper/GetItem", md)
+	if err == nil {
+		t.Fatal("expired token accepted")
+	}
+	if called {
+		t.Fatal("handler reached with an expired token")
+	}
+}
+
+func TestAuthInterceptorRejectsRevokedToken(t *testing.T) {
+	srv, store, _ := newTestServer(t)
+	token, err := auth.GenerateToken(testSecret, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := auth.ParseClaims(testSecret, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := metadata.Pairs("authorization", "Bearer "+token)
+
+	// Sanity: the token works before revocation.
+	if _, _, err := invokeInterceptorOn(t, srv, "/gophkeeper.GophKeeper/GetItem", md); err != nil {
+		t.Fatalf("token rejected before revocation: %v", err)
+	}
+
+	if err := store.RevokeToken(context.Background(), claims.ID, claims.ExpiresAt.Time); err != nil {
+		t.Fatal(err)
+	}
+	_, called, err := invokeInterceptorOn(t, srv, "/gophkeeper.GophKeeper/GetItem", md)
+	if err == nil {
+		t.Fatal("revoked token accepted despite valid signature and expiry")
+	}
+	if called {
+		t.Fatal("handler reached with a revoked token")
+	}
+}
+
+func TestAuthInterceptorExemptsRegisterAndLogin(t *testing.T) {
+	for _, method := range []string{"/gophkeeper.GophKeeper/Register", "/gophkeeper.GophKeeper/Login"} {
+		_, called, err := invokeInterceptor(t, method, nil)
+		if err != nil {
+			t.Errorf("%s blocked without a token: %v", method, err)
+		}
+		if !called {
+			t.Errorf("%s handler not called", method)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestLoginRecordsLastLogin(t *testing.T) {
+	srv, store, _ := newTestServer(t)
+	ctx := context.Background()
+	registered, err := srv.Register(ctx, &pb.RegisterRequest{Login: "watcher", Password: "long-enough-pw"})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	claims, err := srv.tokens.ParseClaims(registered.GetToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	authed := contextWithClaims(ctx, claims)
+
+	before, err := srv.GetProfile(authed, &pb.GetProfileRequest{})
+	if err != nil {
+		t.Fatalf("profile: %v", err)
+	}
+	if before.GetLastLoginAt() != nil {
+		t.Fatalf("last login set before any login: %v", before.GetLastLoginAt())
+	}
+
+	peerCtx := peer.NewContext(ctx, &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.7"), Port: 4242},
+	})
+	start := time.Now()
+	if _, err := srv.Login(peerCtx, &pb.LoginRequest{Login: "watcher", Password: "long-enough-pw"}); err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	user, err := store.GetUserByLogin(ctx, "watcher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.LastLoginAt.IsZero() || user.LastLoginAt.Before(start.Add(-time.Second)) {
+		t.Fatalf("last login not stamped by login: %v", user.LastLoginAt)
+	}
+	if user.LastLoginIP != "192.0.2.7" {
+		t.Fatalf("last login ip = %q, want the peer's 192.0.2.7", user.LastLoginIP)
+	}
+
+	after, err := srv.GetProfile(authed, &pb.GetProfileRequest{})
+	if err != nil {
+		t.Fatalf("profile after login: %v", err)
+	}
+	if after.GetLastLoginAt() == nil || after.GetLastLoginIp() != "192.0.2.7" {
+		t.Fatalf("profile = %+v, want the recorded last login", after)
+	}
+}
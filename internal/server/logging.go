@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDKey contextKey = iota + 1
+
+// LoggingInterceptor assigns every request a random ID, makes it
+// available to handlers via the context, and logs one completion line
+// per RPC. Handlers must log through log(ctx) so their lines carry the
+// same ID.
+func (s *Server) LoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = context.WithValue(ctx, requestIDKey, newRequestID())
+		s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+		started := time.Now()
+		resp, err := handler(ctx, req)
+		s.log(ctx).Info("rpc handled",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration", time.Since(started),
+		)
+		return resp, err
+	}
+}
+
+// log returns the server logger tagged with the request ID, when the
+// call went through the logging interceptor.
+func (s *Server) log(ctx context.Context) *slog.Logger {
+	if id, ok := ctx.Value(requestIDKey).(string); ok {
+		return s.logger.With("request_id", id)
+	}
+	return s.logger
+}
+
+// newRequestID returns a short random hex ID for correlating log lines.
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestLoggingInterceptorTagsRequestsAndHidesSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	srv.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	req := &pb.RegisterRequest{Login: "alice@example.com", Password: "long-enough-pw"}
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Register"}
+	handler := func(ctx context.Context, r any) (any, error) {
+		return srv.Register(ctx, r.(*pb.RegisterRequest))
+	}
+	if _, err := srv.LoggingInterceptor()(context.Background(), req, info, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"request_id"`) {
+		t.Fatalf("log output has no request_id:\n%s", out)
+	}
+	if !strings.Contains(out, "/gophkeeper.GophKeeper/Register") {
+		t.Fatalf("log output does not mention the method:\n%s", out)
+	}
+	if strings.Contains(out, "long-enough-pw") {
+		t.Fatalf("password leaked into the log:\n%s", out)
+	}
+	if strings.Contains(out, "alice@example.com") {
+		t.Fatalf("login leaked into the log at info level:\n%s", out)
+	}
+}
+
+func TestLoggingInterceptorSharesIDAcrossLines(t *testing.T) {
+	var buf bytes.Buffer
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	srv.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/GetItem"}
+	handler := func(ctx context.Context, r any) (any, error) {
+		srv.log(ctx).Info("handler line")
+		return nil, nil
+	}
+	if _, err := srv.LoggingInterceptor()(context.Background(), nil, info, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2:\n%s", len(lines), buf.String())
+	}
+	id := regexp.MustCompile(`"request_id":"[0-9a-f]+"`).FindString(lines[0])
+	if id == "" {
+		t.Fatalf("handler line has no request_id:\n%s", lines[0])
+	}
+	if !strings.Contains(lines[1], id) {
+		t.Fatalf("lines carry different request IDs:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics bundles the server's Prometheus collectors behind its own
+// registry, so tests and multiple servers never collide on the global
+// default.
+type Metrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewMetrics builds the request collectors and registers them.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gophkeeper_rpc_requests_total",
+			Help: "RPCs handled, labeled by method and gRPC status code.",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gophkeeper_rpc_duration_seconds",
+			Help:    "RPC handling latency by method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+	m.registry.MustRegister(m.requests, m.duration)
+	return m
+}
+
+// UnaryInterceptor records a counter and latency observation for every
+// unary RPC.
+func (m *Metrics) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		started := time.Now()
+		resp, err := handler(ctx, req)
+		m.requests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		m.duration.WithLabelValues(info.FullMethod).Observe(time.Since(started).Seconds())
+		return resp, err
+	}
+}
+
+// dbStatser is implemented by storage backends with a database/sql pool.
+type dbStatser interface {
+	DBStats() sql.DBStats
+}
+
+// ObserveDBStats exports connection pool gauges when the store exposes
+// them; the in-memory store has no pool and is left alone.
+func (m *Metrics) ObserveDBStats(store any) {
+	db, ok := store.(dbStatser)
+	if !ok {
+		return
+	}
+	m.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gophkeeper_db_open_connections",
+			Help: "Open connections in the database pool.",
+		}, func() float64 { return float64(db.DBStats().OpenConnections) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "gophkeeper_db_idle_connections",
+			Help: "Idle connections in the database pool.",
+		}, func() float64 { return float64(db.DBStats().Idle) }),
+	)
+}
+
+// Handler serves the registry in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
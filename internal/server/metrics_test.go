@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsInterceptorCountsRequests(t *testing.T) {
+	m := NewMetrics()
+	interceptor := m.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/GetItem"}
+
+	ok := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	fail := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := interceptor(context.Background(), nil, info, ok); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := interceptor(context.Background(), nil, info, fail); !errors.Is(err, status.Error(codes.NotFound, "missing")) {
+		t.Fatalf("interceptor swallowed the handler error: %v", err)
+	}
+
+	got := testutil.ToFloat64(m.requests.WithLabelValues("/gophkeeper.GophKeeper/GetItem", "OK"))
+	if got != 3 {
+		t.Fatalf("OK counter = %v, want 3", got)
+	}
+	got = testutil.ToFloat64(m.requests.WithLabelValues("/gophkeeper.GophKeeper/GetItem", "NotFound"))
+	if got != 1 {
+		t.Fatalf("NotFound counter = %v, want 1", got)
+	}
+
+	if count := testutil.CollectAndCount(m.duration); count == 0 {
+		t.Fatal("no latency observations recorded")
+	}
+}
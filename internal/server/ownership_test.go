@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// TestCrossUserAccessDenied guesses another user's item ID through every
+// item RPC and expects NotFound each time: ownership is part of the
+// lookup key, so a foreign ID behaves exactly like a missing one.
+func TestCrossUserAccessDenied(t *testing.T) {
+	srv, store, ownerID := newTestServer(t)
+	ownerCtx := authedContext(ownerID)
+
+	intruder, err := store.CreateUser(context.Background(), "intruder", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	intruderCtx := authedContext(intruder.ID)
+
+	added, err := srv.AddItem(ownerCtx, &pb.AddItemRequest{
+		Item: &pb.Item{Name: "secret", Type: "text", Data: []byte("owner-only")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := added.GetId()
+
+	if _, err := srv.GetItem(intruderCtx, &pb.GetItemRequest{Id: id}); status.Code(err) != codes.NotFound {
+		t.Fatalf("cross-user GetItem: got %v, want NotFound", err)
+	}
+	if _, err := srv.UpdateItem(intruderCtx, &pb.UpdateItemRequest{
+		Item: &pb.Item{Id: id, Data: []byte("overwritten")},
+	}); status.Code(err) != codes.NotFound {
+		t.Fatalf("cross-user UpdateItem: got %v, want NotFound", err)
+	}
+	if _, err := srv.DeleteItem(intruderCtx, &pb.DeleteItemRequest{Id: id}); status.Code(err) != codes.NotFound {
+		t.Fatalf("cross-user DeleteItem: got %v, want NotFound", err)
+	}
+	if _, err := srv.PurgeItem(intruderCtx, &pb.PurgeItemRequest{Id: id}); status.Code(err) != codes.NotFound {
+		t.Fatalf("cross-user PurgeItem: got %v, want NotFound", err)
+	}
+
+	// The failed attempts must leave the owner's item untouched.
+	got, err := srv.GetItem(ownerCtx, &pb.GetItemRequest{Id: id})
+	if err != nil {
+		t.Fatalf("owner lost access to own item: %v", err)
+	}
+	if string(got.GetItem().GetData()) != "owner-only" || got.GetItem().GetVersion() != 1 {
+		t.Fatalf("owner's item was modified: %+v", got.GetItem())
+	}
+}
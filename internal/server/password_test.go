@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestChangePassword(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	ctx := context.Background()
+	registered, err := srv.Register(ctx, &pb.RegisterRequest{Login: "changer", Password: "old-password-1"})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	claims, err := srv.tokens.ParseClaims(registered.GetToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	authed := contextWithClaims(ctx, claims)
+
+	// The wrong current password is rejected and changes nothing.
+	_, err = srv.ChangePassword(authed, &pb.ChangePasswordRequest{
+		CurrentPassword: "not-the-password", NewPassword: "new-password-1",
+	})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("wrong current password: got %v, want Unauthenticated", err)
+	}
+	if _, err := srv.Login(ctx, &pb.LoginRequest{Login: "changer", Password: "old-password-1"}); err != nil {
+		t.Fatalf("old password stopped working after a failed change: %v", err)
+	}
+
+	if _, err := srv.ChangePassword(authed, &pb.ChangePasswordRequest{
+		CurrentPassword: "old-password-1", NewPassword: "new-password-1",
+	}); err != nil {
+		t.Fatalf("change password: %v", err)
+	}
+
+	// Only the new password logs in now.
+	if _, err := srv.Login(ctx, &pb.LoginRequest{Login: "changer", Password: "old-password-1"}); err == nil {
+		t.Fatal("old password still logs in")
+	}
+	if _, err := srv.Login(ctx, &pb.LoginRequest{Login: "changer", Password: "new-password-1"}); err != nil {
+		t.Fatalf("new password rejected: %v", err)
+	}
+
+	// The refresh token issued before the change is dead.
+	if _, err := srv.Refresh(ctx, &pb.RefreshRequest{RefreshToken: registered.GetRefreshToken()}); err == nil {
+		t.Fatal("old refresh token survived the password change")
+	}
+}
+
+func TestChangePasswordEnforcesPolicy(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	registered, err := srv.Register(context.Background(), &pb.RegisterRequest{Login: "weak", Password: "old-password-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := srv.tokens.ParseClaims(registered.GetToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	authed := contextWithClaims(context.Background(), claims)
+	_, err = srv.ChangePassword(authed, &pb.ChangePasswordRequest{
+		CurrentPassword: "old-password-1", NewPassword: "short",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("weak new password: got %v, want InvalidArgument", err)
+	}
+}
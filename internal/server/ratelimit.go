@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/peer"
+)
+
+// Defaults for login throttling: an account or address locks for the
+// rest of the window after this many failed attempts.
+const (
+	defaultLoginAttempts = 5
+	defaultLoginWindow   = 15 * time.Minute
+)
+
+// loginLimiter counts failed login attempts per key (login name or
+// client IP) and blocks further attempts once the limit is reached
+// within the window. Successful logins clear the counter.
+type loginLimiter struct {
+	mu       sync.Mutex
+	limit    int
+	window   time.Duration
+	now      func() time.Time // stubbed in tests
+	failures map[string]*loginFailures
+}
+
+type loginFailures struct {
+	count int
+	since time.Time
+}
+
+func newLoginLimiter(limit int, window time.Duration) *loginLimiter {
+	return &loginLimiter{
+		limit:    limit,
+		window:   window,
+		now:      time.Now,
+		failures: map[string]*loginFailures{},
+	}
+}
+
+// blocked reports whether key has exhausted its attempts within the
+// window. A record whose window has elapsed is dropped.
+func (l *loginLimiter) blocked(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rec, ok := l.failures[key]
+	if !ok {
+		return false
+	}
+	if l.now().Sub(rec.since) > l.window {
+		delete(l.failures, key)
+		return false
+	}
+	return rec.count >= l.limit
+}
+
+// fail records a failed attempt for key, starting a new window if the
+// previous one has elapsed.
+func (l *loginLimiter) fail(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	rec, ok := l.failures[key]
+	if !ok || now.Sub(rec.since) > l.window {
+		l.failures[key] = &loginFailures{count: 1, since: now}
+		return
+	}
+	rec.count++
+}
+
+// reset clears the counter for key after a successful login.
+func (l *loginLimiter) reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.failures, key)
+}
+
+// loginRateKeys returns the throttling keys for a login attempt: one
+// per account name and, when the peer address is known, one per client
+// IP so spraying across accounts is also slowed down.
+func loginRateKeys(ctx context.Context, login string) []string {
+	keys := []string{"login:" + login}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		keys = append(keys, "ip:"+host)
+	}
+	return keys
+}
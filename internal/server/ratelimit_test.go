@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+const lockoutPassword = "long-enough-pw"
+
+// newLockoutServer returns a server with a tight login limit and one
+// registered account.
+func newLockoutServer(t *testing.T) *Server {
+	t.Helper()
+	srv, _, _ := newTestServer(t)
+	srv.SetLoginRateLimit(3, time.Minute)
+	if _, err := srv.Register(context.Background(), &pb.RegisterRequest{Login: "carol", Password: lockoutPassword}); err != nil {
+		t.Fatal(err)
+	}
+	return srv
+}
+
+func failLogins(t *testing.T, srv *Server, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		_, err := srv.Login(context.Background(), &pb.LoginRequest{Login: "carol", Password: "wrong-password"})
+		if err == nil {
+			t.Fatal("login with a wrong password succeeded")
+		}
+		if strings.Contains(err.Error(), "too many") {
+			t.Fatalf("locked out after %d attempts, limit is 3", i+1)
+		}
+	}
+}
+
+func TestLoginLockoutAfterLimit(t *testing.T) {
+	srv := newLockoutServer(t)
+	failLogins(t, srv, 3)
+
+	// The fourth attempt is rejected with the distinct lockout message,
+	// even with the correct password.
+	_, err := srv.Login(context.Background(), &pb.LoginRequest{Login: "carol", Password: lockoutPassword})
+	if err == nil || !strings.Contains(err.Error(), "too many") {
+		t.Fatalf("got %v, want a too-many-attempts rejection", err)
+	}
+}
+
+func TestLoginLockoutResetsOnSuccess(t *testing.T) {
+	srv := newLockoutServer(t)
+	failLogins(t, srv, 2)
+
+	if _, err := srv.Login(context.Background(), &pb.LoginRequest{Login: "carol", Password: lockoutPassword}); err != nil {
+		t.Fatalf("login under the limit failed: %v", err)
+	}
+
+	// The counter is cleared: another two failures do not lock.
+	failLogins(t, srv, 2)
+	if _, err := srv.Login(context.Background(), &pb.LoginRequest{Login: "carol", Password: lockoutPassword}); err != nil {
+		t.Fatalf("login after reset failed: %v", err)
+	}
+}
+
+func TestLoginLockoutExpiresWithWindow(t *testing.T) {
+	srv := newLockoutServer(t)
+	failLogins(t, srv, 3)
+
+	// Pretend the lockout window has elapsed.
+	srv.loginLimiter.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+	if _, err := srv.Login(context.Background(), &pb.LoginRequest{Login: "carol", Password: lockoutPassword}); err != nil {
+		t.Fatalf("login after the window elapsed failed: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+)
+
+func TestRecoveryInterceptorCatchesPanics(t *testing.T) {
+	var buf bytes.Buffer
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	srv.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/GetItem"}
+	boom := func(ctx context.Context, req any) (any, error) {
+		var item *struct{ Name string }
+		return item.Name, nil // deliberate nil dereference
+	}
+
+	_, err := srv.RecoveryInterceptor()(context.Background(), nil, info, boom)
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("panicking handler returned %v, want codes.Internal", err)
+	}
+	if !strings.Contains(buf.String(), "panic in handler") {
+		t.Fatalf("panic was not logged:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"stack"`) {
+		t.Fatalf("log line has no stack trace:\n%s", buf.String())
+	}
+
+	// The interceptor must stay usable after a panic.
+	ok := func(ctx context.Context, req any) (any, error) { return "fine", nil }
+	resp, err := srv.RecoveryInterceptor()(context.Background(), nil, info, ok)
+	if err != nil || resp != "fine" {
+		t.Fatalf("healthy handler after panic: resp=%v err=%v", resp, err)
+	}
+}
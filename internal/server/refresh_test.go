@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ar11/gophkeeper/pkg/auth"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestRefreshRotatesTokens(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	ctx := context.Background()
+
+	registered, err := srv.Register(ctx, &pb.RegisterRequest{Login: "alice", Password: "long-enough-pw"})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if registered.GetRefreshToken() == "" {
+		t.Fatal("register returned no refresh token")
+	}
+
+	refreshed, err := srv.Refresh(ctx, &pb.RefreshRequest{RefreshToken: registered.GetRefreshToken()})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if refreshed.GetToken() == "" || refreshed.GetRefreshToken() == "" {
+		t.Fatal("refresh returned an incomplete token pair")
+	}
+	if refreshed.GetRefreshToken() == registered.GetRefreshToken() {
+		t.Fatal("refresh token was not rotated")
+	}
+
+	// The new access token must verify against the same secret.
+	userID, err := auth.ParseToken(testSecret, refreshed.GetToken())
+	if err != nil {
+		t.Fatalf("new access token invalid: %v", err)
+	}
+	registeredID, err := auth.ParseToken(testSecret, registered.GetToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userID != registeredID {
+		t.Fatalf("refreshed token is for user %d, want %d", userID, registeredID)
+	}
+}
+
+func TestRefreshRejectsReusedToken(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	ctx := context.Background()
+
+	registered, err := srv.Register(ctx, &pb.RegisterRequest{Login: "bob", Password: "long-enough-pw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Refresh(ctx, &pb.RefreshRequest{RefreshToken: registered.GetRefreshToken()}); err != nil {
+		t.Fatalf("first refresh: %v", err)
+	}
+	if _, err := srv.Refresh(ctx, &pb.RefreshRequest{RefreshToken: registered.GetRefreshToken()}); err == nil {
+		t.Fatal("reused refresh token accepted")
+	}
+}
+
+func TestRefreshRejectsExpiredToken(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+
+	if err := store.CreateRefreshToken(context.Background(), userID, "stale", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Refresh(context.Background(), &pb.RefreshRequest{RefreshToken: "stale"}); err == nil {
+		t.Fatal("expired refresh token accepted")
+	}
+	// Expired tokens are removed on use, so a retry fails identically.
+	if _, err := store.GetRefreshToken(context.Background(), "stale"); err == nil {
+		t.Fatal("expired token still stored after use")
+	}
+}
+
+func TestRefreshRejectsUnknownToken(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	if _, err := srv.Refresh(context.Background(), &pb.RefreshRequest{RefreshToken: "never-issued"}); err == nil {
+		t.Fatal("unknown refresh token accepted")
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestRegisterNormalizesLogin(t *testing.T) {
+	srv, store, _ := newTestServer(t)
+	if _, err := srv.Register(context.Background(), &pb.RegisterRequest{
+		Login: "  Alice@Example.COM ", Password: "long-enough-pw",
+	}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if _, err := store.GetUserByLogin(context.Background(), "alice@example.com"); err != nil {
+		t.Fatalf("normalized login not stored: %v", err)
+	}
+
+	// The same address in another case is the same account.
+	_, err := srv.Register(context.Background(), &pb.RegisterRequest{
+		Login: "ALICE@example.com", Password: "long-enough-pw",
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("case-variant duplicate: got %v, want AlreadyExists", err)
+	}
+
+	// Login with yet another case still finds the account.
+	if _, err := srv.Login(context.Background(), &pb.LoginRequest{
+		Login: "Alice@EXAMPLE.com", Password: "long-enough-pw",
+	}); err != nil {
+		t.Fatalf("case-insensitive login: %v", err)
+	}
+}
+
+func TestRegisterRejectsMalformedEmail(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	for _, login := range []string{"foo@", "@example.com", "a@b@c", "spaced name@example.com"} {
+		_, err := srv.Register(context.Background(), &pb.RegisterRequest{
+			Login: login, Password: "long-enough-pw",
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("register %q: got %v, want InvalidArgument", login, err)
+		}
+	}
+}
+
+func TestRegisterPlainNameStillAllowed(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	if _, err := srv.Register(context.Background(), &pb.RegisterRequest{
+		Login: "alice", Password: "long-enough-pw",
+	}); err != nil {
+		t.Fatalf("plain login rejected: %v", err)
+	}
+}
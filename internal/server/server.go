@@ -0,0 +1,840 @@
+// Package server implements the GophKeeper gRPC service.
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/mail"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ar11/gophkeeper/internal/crypto"
+	"github.com/ar11/gophkeeper/internal/models"
+	"github.com/ar11/gophkeeper/internal/storage"
+	"github.com/ar11/gophkeeper/pkg/auth"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// defaultPageSize caps ListItems responses when the client does not ask
+// for a specific limit.
+const defaultPageSize = 100
+
+// Server implements pb.GophKeeperServer on top of a Storage backend.
+type Server struct {
+	pb.UnimplementedGophKeeperServer
+
+	store          storage.Storage
+	tokens         *auth.TokenConfig
+	passwordPolicy crypto.PasswordPolicy
+	loginLimiter   *loginLimiter
+	requestLimiter *requestLimiter
+	logger         *slog.Logger
+	maxUploadSize  int64
+	maxItemSize    int64
+	bcryptCost     int
+	idempotency    *idempotencyCache
+	inFlight       atomic.Int64
+}
+
+// New returns a Server using store for persistence and HS256 tokens
+// signed with tokenSecret, the default scheme.
+func New(store storage.Storage, tokenSecret []byte) *Server {
+	return NewWithTokenConfig(store, auth.NewHS256Config(tokenSecret))
+}
+
+// NewWithTokenConfig returns a Server with full control over token
+// signing, e.g. RS256 or a custom duration.
+func NewWithTokenConfig(store storage.Storage, tokens *auth.TokenConfig) *Server {
+	return &Server{
+		store:         store,
+		tokens:        tokens,
+		loginLimiter:  newLoginLimiter(defaultLoginAttempts, defaultLoginWindow),
+		logger:        slog.Default(),
+		maxUploadSize: defaultMaxUploadSize,
+		maxItemSize:   defaultMaxItemSize,
+		idempotency:   newIdempotencyCache(defaultIdempotencyTTL),
+	}
+}
+
+// SetLogger replaces the default logger, e.g. with one writing JSON at
+// a configured level.
+func (s *Server) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// SetLoginRateLimit overrides how many failed login attempts are
+// allowed per account (and per client IP) within the window.
+func (s *Server) SetLoginRateLimit(attempts int, window time.Duration) {
+	s.loginLimiter = newLoginLimiter(attempts, window)
+}
+
+// SetPasswordPolicy overrides the default account-password strength
+// requirements applied during registration.
+func (s *Server) SetPasswordPolicy(policy crypto.PasswordPolicy) {
+	s.passwordPolicy = policy
+}
+
+// SetBcryptCost overrides the bcrypt cost used for new password hashes.
+// Existing hashes stored at a lower cost are transparently upgraded the
+// next time the user logs in. Zero or less keeps bcrypt's default.
+func (s *Server) SetBcryptCost(cost int) {
+	s.bcryptCost = cost
+}
+
+// Register creates a new account and returns an auth token.
+func (s *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.AuthResponse, error) {
+	login := normalizeLogin(req.GetLogin())
+	if login == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "login and password are required")
+	}
+	if err := validateLogin(login); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.passwordPolicy.Validate(req.GetPassword()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	hash, err := auth.HashPasswordCost(req.GetPassword(), s.bcryptCost)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	user, err := s.store.CreateUser(ctx, login, hash)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp, err := s.issueTokens(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.log(ctx).Info("user registered", "user_id", user.ID)
+	return resp, nil
+}
+
+// ChangePassword replaces the caller's account password after
+// verifying the current one. Every existing session is ended: refresh
+// tokens are deleted and the current access token is blacklisted, so
+// the new password must be used to log in again.
+func (s *Server) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	claims, err := claimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.store.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if !auth.CheckPassword(user.PasswordHash, req.GetCurrentPassword()) {
+		return nil, status.Error(codes.Unauthenticated, "invalid password")
+	}
+	if err := s.passwordPolicy.Validate(req.GetNewPassword()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	hash, err := auth.HashPasswordCost(req.GetNewPassword(), s.bcryptCost)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.store.UpdateUserPassword(ctx, claims.UserID, hash); err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.store.DeleteUserRefreshTokens(ctx, claims.UserID); err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.store.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return nil, grpcError(err)
+	}
+	s.log(ctx).Info("password changed", "user_id", claims.UserID)
+	return &pb.ChangePasswordResponse{}, nil
+}
+
+// normalizeLogin canonicalizes a login for storage and lookup:
+// surrounding whitespace is dropped and the login is lowercased, so
+// "User@x" and "user@x" name the same account.
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
+
+// validateLogin rejects malformed logins. A login containing "@" is
+// held to email-address syntax, since that is what users put there;
+// plain names without "@" stay allowed.
+func validateLogin(login string) error {
+	if !strings.Contains(login, "@") {
+		return nil
+	}
+	addr, err := mail.ParseAddress(login)
+	if err != nil || addr.Address != login {
+		return errors.New("login is not a valid email address")
+	}
+	return nil
+}
+
+// Login authenticates an existing account and returns an auth token.
+// Failed attempts count against both the account and the client IP;
+// once either is over the limit the attempt is rejected before any
+// password check happens.
+func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthResponse, error) {
+	login := normalizeLogin(req.GetLogin())
+	keys := loginRateKeys(ctx, login)
+	for _, key := range keys {
+		if s.loginLimiter.blocked(key) {
+			return nil, status.Error(codes.ResourceExhausted, "too many login attempts, try again later")
+		}
+	}
+	user, err := s.store.GetUserByLogin(ctx, login)
+	if err != nil || !auth.CheckPassword(user.PasswordHash, req.GetPassword()) {
+		for _, key := range keys {
+			s.loginLimiter.fail(key)
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid login or password")
+	}
+	for _, key := range keys {
+		s.loginLimiter.reset(key)
+	}
+	// Best-effort: the stamp is informational and must not fail the
+	// login.
+	if err := s.store.UpdateUserLastLogin(ctx, user.ID, peerIP(ctx)); err != nil {
+		s.log(ctx).Warn("record last login failed", "user_id", user.ID, "error", err)
+	}
+	if auth.NeedsRehash(user.PasswordHash, s.bcryptCost) {
+		// Best effort: a failed upgrade must not fail the login.
+		if hash, err := auth.HashPasswordCost(req.GetPassword(), s.bcryptCost); err == nil {
+			if err := s.store.UpdateUserPassword(ctx, user.ID, hash); err != nil {
+				s.log(ctx).Warn("password hash upgrade failed", "user_id", user.ID, "error", err)
+			}
+		}
+	}
+	resp, err := s.issueTokens(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.log(ctx).Info("user logged in", "user_id", user.ID)
+	return resp, nil
+}
+
+// Refresh exchanges a valid refresh token for a fresh token pair. The
+// used token is revoked, so each refresh token works exactly once.
+func (s *Server) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.AuthResponse, error) {
+	rt, err := s.store.GetRefreshToken(ctx, req.GetRefreshToken())
+	if errors.Is(err, storage.ErrRefreshTokenNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.store.DeleteRefreshToken(ctx, rt.Token); err != nil {
+		return nil, grpcError(err)
+	}
+	if time.Now().After(rt.ExpiresAt) {
+		return nil, status.Error(codes.Unauthenticated, "refresh token expired")
+	}
+	return s.issueTokens(ctx, rt.UserID)
+}
+
+// Logout revokes the caller's access token (by jti, until its natural
+// expiry) and deletes the refresh token if one was sent along.
+func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	claims, err := claimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return nil, grpcError(err)
+	}
+	if rt := req.GetRefreshToken(); rt != "" {
+		if err := s.store.DeleteRefreshToken(ctx, rt); err != nil {
+			return nil, grpcError(err)
+		}
+	}
+	s.log(ctx).Info("user logged out", "user_id", claims.UserID)
+	return &pb.LogoutResponse{}, nil
+}
+
+// DeleteAccount permanently removes the caller's account and everything
+// it owns. The password is re-checked so a stolen token alone cannot
+// destroy the vault, and the access token is revoked so it stops
+// working immediately.
+func (s *Server) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	claims, err := claimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.store.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if !auth.CheckPassword(user.PasswordHash, req.GetPassword()) {
+		return nil, status.Error(codes.Unauthenticated, "invalid password")
+	}
+	// Refresh tokens go with the user row; the current access token
+	// must be blacklisted explicitly.
+	if err := s.store.RevokeToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.store.DeleteUser(ctx, claims.UserID); err != nil {
+		return nil, grpcError(err)
+	}
+	s.log(ctx).Info("account deleted", "user_id", claims.UserID)
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func (s *Server) GetProfile(ctx context.Context, _ *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
+	claims, err := claimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.store.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.GetProfileResponse{
+		UserId:         user.ID,
+		Login:          user.Login,
+		TokenExpiresAt: timestamppb.New(claims.ExpiresAt.Time),
+		LastLoginIp:    user.LastLoginIP,
+	}
+	if !user.LastLoginAt.IsZero() {
+		resp.LastLoginAt = timestamppb.New(user.LastLoginAt)
+	}
+	return resp, nil
+}
+
+// issueTokens creates an access/refresh token pair for userID and
+// records the refresh token for later revocation.
+func (s *Server) issueTokens(ctx context.Context, userID int64) (*pb.AuthResponse, error) {
+	token, err := s.tokens.GenerateToken(userID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	refresh, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.store.CreateRefreshToken(ctx, userID, refresh, time.Now().Add(auth.RefreshTokenDuration)); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.AuthResponse{Token: token, RefreshToken: refresh}, nil
+}
+
+// AddItem stores a new encrypted item for the authenticated user.
+func (s *Server) AddItem(ctx context.Context, req *pb.AddItemRequest) (*pb.AddItemResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key := req.GetIdempotencyKey(); key != "" {
+		if id, ok := s.idempotency.get(userID, key); ok {
+			// A replayed retry: the item was already created.
+			return &pb.AddItemResponse{Id: id}, nil
+		}
+	}
+	item := itemFromProto(req.GetItem())
+	item.UserID = userID
+	if err := s.validateItem(item); err != nil {
+		return nil, err
+	}
+	id, err := s.store.AddItem(ctx, item)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if key := req.GetIdempotencyKey(); key != "" {
+		s.idempotency.put(userID, key, id)
+	}
+	s.audit(ctx, userID, auditActionAdd, id)
+	return &pb.AddItemResponse{Id: id}, nil
+}
+
+// defaultMaxItemSize caps the encrypted payload of a unary AddItem.
+// Larger blobs belong on the UploadItem stream.
+const defaultMaxItemSize = 1 << 20
+
+// SetMaxItemSize overrides the encrypted-payload cap for unary adds.
+// Zero or negative disables the check.
+func (s *Server) SetMaxItemSize(limit int64) {
+	s.maxItemSize = limit
+}
+
+// validateItem rejects items the storage layer would otherwise accept
+// blindly: blank or overlong names, unknown types and oversized
+// payloads all come back as InvalidArgument.
+func (s *Server) validateItem(item *models.Item) error {
+	if err := s.validateItemMeta(item); err != nil {
+		return err
+	}
+	if s.maxItemSize > 0 && int64(len(item.Data)) > s.maxItemSize {
+		return status.Errorf(codes.InvalidArgument, "item data exceeds the %d byte limit", s.maxItemSize)
+	}
+	return nil
+}
+
+// validateItemMeta is validateItem without the payload-size check, for
+// streamed uploads whose total size is capped separately.
+func (s *Server) validateItemMeta(item *models.Item) error {
+	if err := item.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !models.KnownType(item.Type) {
+		return status.Errorf(codes.InvalidArgument, "unknown item type %q", item.Type)
+	}
+	return nil
+}
+
+// BatchAddItems stores many items atomically. Name collisions — with
+// stored items or within the batch — fail the whole request; the
+// per-item results say which items were at fault, so the caller can fix
+// the batch and resend it.
+func (s *Server) BatchAddItems(ctx context.Context, req *pb.BatchAddItemsRequest) (*pb.BatchAddItemsResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no items in batch")
+	}
+
+	results := make([]*pb.BatchItemResult, 0, len(req.GetItems()))
+	items := make([]*models.Item, 0, len(req.GetItems()))
+	seen := make(map[string]bool, len(req.GetItems()))
+	failed := false
+	for _, pi := range req.GetItems() {
+		item := itemFromProto(pi)
+		item.UserID = userID
+		items = append(items, item)
+
+		result := &pb.BatchItemResult{Name: item.Name}
+		results = append(results, result)
+		switch {
+		case item.Name == "":
+			result.Error = "item name is required"
+		case seen[item.Name]:
+			result.Error = "duplicate name within the batch"
+		default:
+			// Batch items pass the same checks a unary AddItem would.
+			if verr := s.validateItem(item); verr != nil {
+				result.Error = status.Convert(verr).Message()
+				break
+			}
+			_, err := s.store.GetItemByName(ctx, userID, item.Name)
+			if err == nil {
+				result.Error = "an item with this name already exists"
+			} else if !errors.Is(err, storage.ErrItemNotFound) {
+				return nil, grpcError(err)
+			}
+		}
+		seen[item.Name] = true
+		if result.Error != "" {
+			failed = true
+		}
+	}
+	if failed {
+		return &pb.BatchAddItemsResponse{Results: results}, nil
+	}
+
+	ids, err := s.store.AddItems(ctx, items)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	for i, id := range ids {
+		results[i].Id = id
+	}
+	return &pb.BatchAddItemsResponse{Results: results, Applied: true}, nil
+}
+
+// GetItem fetches one item by ID or name.
+func (s *Server) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.GetItemResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var item *models.Item
+	if req.GetId() != 0 {
+		item, err = s.store.GetItem(ctx, userID, req.GetId())
+	} else {
+		item, err = s.store.GetItemByName(ctx, userID, req.GetName())
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if !item.ExpiresAt.IsZero() && !item.ExpiresAt.After(time.Now()) {
+		return nil, status.Error(codes.FailedPrecondition, "item expired")
+	}
+	if req.GetIfVersion() > 0 && item.Version <= req.GetIfVersion() {
+		return &pb.GetItemResponse{NotModified: true}, nil
+	}
+	s.audit(ctx, userID, auditActionGet, item.ID)
+	return &pb.GetItemResponse{Item: itemToProto(item)}, nil
+}
+
+// BatchGetItems fetches several items by name in one response. Names
+// that are missing or expired get a per-result error instead of
+// failing the batch.
+func (s *Server) BatchGetItems(ctx context.Context, req *pb.BatchGetItemsRequest) (*pb.BatchGetItemsResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetNames()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no names in batch")
+	}
+
+	results := make([]*pb.BatchGetItemResult, 0, len(req.GetNames()))
+	for _, name := range req.GetNames() {
+		result := &pb.BatchGetItemResult{Name: name}
+		results = append(results, result)
+		if name == "" {
+			result.Error = "item name is required"
+			continue
+		}
+		item, err := s.store.GetItemByName(ctx, userID, name)
+		switch {
+		case errors.Is(err, storage.ErrItemNotFound):
+			result.Error = "no item with this name"
+			continue
+		case err != nil:
+			return nil, grpcError(err)
+		}
+		if !item.ExpiresAt.IsZero() && !item.ExpiresAt.After(time.Now()) {
+			result.Error = "item expired"
+			continue
+		}
+		s.audit(ctx, userID, auditActionGet, item.ID)
+		result.Item = itemToProto(item)
+	}
+	return &pb.BatchGetItemsResponse{Results: results}, nil
+}
+
+// ListItems returns one page of the user's items plus the total count.
+func (s *Server) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.GetLimit()
+	if limit <= 0 || limit > defaultPageSize {
+		limit = defaultPageSize
+	}
+	if err := storage.ValidateSort(req.GetSortBy(), req.GetOrder()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	items, total, err := s.store.ListItems(ctx, userID, limit, req.GetOffset(), req.GetMetadataFilter(), req.GetTags(), req.GetSortBy(), req.GetOrder())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListItemsResponse{Total: total}
+	for _, item := range items {
+		resp.Items = append(resp.Items, itemToProto(item))
+	}
+	s.audit(ctx, userID, auditActionList, 0)
+	return resp, nil
+}
+
+// SearchItems returns items whose name contains the query string.
+// ListTrash returns the caller's soft-deleted items, most recently
+// deleted first, so a mistaken delete can be undone with RestoreItem.
+func (s *Server) ListTrash(ctx context.Context, req *pb.ListTrashRequest) (*pb.ListTrashResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.store.ListTrash(ctx, userID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListTrashResponse{}
+	for _, item := range items {
+		resp.Items = append(resp.Items, itemToProto(item))
+	}
+	s.audit(ctx, userID, auditActionList, 0)
+	return resp, nil
+}
+
+// RestoreItem clears a soft-deleted item's tombstone; the item
+// reappears in normal reads with a bumped version.
+func (s *Server) RestoreItem(ctx context.Context, req *pb.RestoreItemRequest) (*pb.RestoreItemResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "item id is required")
+	}
+	if err := s.store.RestoreItem(ctx, userID, req.GetId()); err != nil {
+		return nil, grpcError(err)
+	}
+	item, err := s.store.GetItem(ctx, userID, req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	s.audit(ctx, userID, auditActionRestore, item.ID)
+	return &pb.RestoreItemResponse{Item: itemToProto(item)}, nil
+}
+
+// Stats summarizes the caller's vault per item type. It reads only
+// aggregates, never payloads.
+func (s *Server) Stats(ctx context.Context, req *pb.StatsRequest) (*pb.StatsResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.store.CountItemsByType(ctx, userID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	types := make([]string, 0, len(stats))
+	for typ := range stats {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	resp := &pb.StatsResponse{}
+	for _, typ := range types {
+		st := stats[typ]
+		resp.Types = append(resp.Types, &pb.TypeStats{
+			Type:      typ,
+			Count:     st.Count,
+			TotalSize: st.TotalSize,
+			Oldest:    timestamppb.New(st.Oldest),
+			Newest:    timestamppb.New(st.Newest),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) SearchItems(ctx context.Context, req *pb.SearchItemsRequest) (*pb.SearchItemsResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.store.SearchItemsByName(ctx, userID, req.GetQuery())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.SearchItemsResponse{}
+	for _, item := range items {
+		resp.Items = append(resp.Items, itemToProto(item))
+	}
+	return resp, nil
+}
+
+// UpdateItem overwrites an existing item's payload and metadata.
+func (s *Server) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest) (*pb.UpdateItemResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	item := itemFromProto(req.GetItem())
+	item.UserID = userID
+	var updated *models.Item
+	if item.ID == 0 {
+		// Name-keyed update: the version check happens atomically in
+		// the store, so no read-then-write race.
+		if err := s.store.UpdateItemByName(ctx, item); err != nil {
+			return nil, grpcError(err)
+		}
+		updated, err = s.store.GetItemByName(ctx, userID, item.Name)
+	} else {
+		if err := s.store.UpdateItem(ctx, item); err != nil {
+			return nil, grpcError(err)
+		}
+		updated, err = s.store.GetItem(ctx, userID, item.ID)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	s.audit(ctx, userID, auditActionUpdate, updated.ID)
+	return &pb.UpdateItemResponse{Version: updated.Version}, nil
+}
+
+// DeleteItem removes an item by ID or name.
+func (s *Server) DeleteItem(ctx context.Context, req *pb.DeleteItemRequest) (*pb.DeleteItemResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := req.GetId()
+	if id == 0 {
+		item, err := s.store.GetItemByName(ctx, userID, req.GetName())
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		id = item.ID
+	}
+	if err := s.store.DeleteItem(ctx, userID, id); err != nil {
+		return nil, grpcError(err)
+	}
+	s.audit(ctx, userID, auditActionDelete, id)
+	return &pb.DeleteItemResponse{}, nil
+}
+
+// BatchDeleteItems soft-deletes many items in one transaction. Names
+// without a live item get a per-result error; the rest are deleted.
+func (s *Server) BatchDeleteItems(ctx context.Context, req *pb.BatchDeleteItemsRequest) (*pb.BatchDeleteItemsResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetNames()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no names in batch")
+	}
+	missing, err := s.store.DeleteItems(ctx, userID, req.GetNames())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	// A count, not a set: a duplicated name deletes once and the other
+	// occurrence reports the miss.
+	missed := make(map[string]int, len(missing))
+	for _, name := range missing {
+		missed[name]++
+	}
+	resp := &pb.BatchDeleteItemsResponse{}
+	for _, name := range req.GetNames() {
+		result := &pb.BatchDeleteItemResult{Name: name}
+		if missed[name] > 0 {
+			missed[name]--
+			result.Error = "no item with this name"
+		} else {
+			resp.Deleted++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	if resp.Deleted > 0 {
+		s.audit(ctx, userID, auditActionDelete, 0)
+	}
+	return resp, nil
+}
+
+// PurgeItem permanently removes an item by ID or name, bypassing the
+// soft-delete retention used by DeleteItem.
+func (s *Server) PurgeItem(ctx context.Context, req *pb.PurgeItemRequest) (*pb.PurgeItemResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.PurgeItem(ctx, userID, req.GetId(), req.GetName()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.PurgeItemResponse{}, nil
+}
+
+// Sync applies the client's local changes and returns items changed on
+// the server since the client's last sync time. A local change whose
+// base version no longer matches the server's current version is not
+// applied; it is reported as a conflict with both versions so the user
+// can resolve it.
+func (s *Server) Sync(ctx context.Context, req *pb.SyncRequest) (*pb.SyncResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SyncResponse{}
+	for _, change := range req.GetLocalChanges() {
+		local := itemFromProto(change)
+		local.UserID = userID
+
+		var current *models.Item
+		if local.ID != 0 {
+			current, err = s.store.GetItem(ctx, userID, local.ID)
+		} else {
+			current, err = s.store.GetItemByName(ctx, userID, local.Name)
+		}
+		switch {
+		case errors.Is(err, storage.ErrItemNotFound):
+			// New on the client: create it server-side.
+			resp.PlannedAdds++
+			if req.GetDryRun() {
+				continue
+			}
+			if _, err := s.store.AddItem(ctx, local); err != nil {
+				return nil, grpcError(err)
+			}
+		case err != nil:
+			return nil, grpcError(err)
+		case current.Version == local.Version:
+			// Clean update: nobody touched it since the client's base.
+			resp.PlannedUpdates++
+			if req.GetDryRun() {
+				continue
+			}
+			local.ID = current.ID
+			if err := s.store.UpdateItem(ctx, local); err != nil {
+				return nil, grpcError(err)
+			}
+		default:
+			// Both sides changed it since the client's base version.
+			resp.Conflicts = append(resp.Conflicts, &pb.SyncConflict{
+				Local:  change,
+				Server: itemToProto(current),
+			})
+		}
+	}
+	if !req.GetDryRun() {
+		resp.PlannedAdds, resp.PlannedUpdates = 0, 0
+	}
+
+	if pageSize := req.GetPageSize(); pageSize > 0 {
+		var cursorAt time.Time
+		if req.GetCursorUpdatedAt() != nil {
+			cursorAt = req.GetCursorUpdatedAt().AsTime()
+		}
+		items, more, err := s.store.ItemsSincePage(ctx, userID,
+			req.GetSince().AsTime(), cursorAt, req.GetCursorId(), pageSize)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		for _, item := range items {
+			resp.Items = append(resp.Items, itemToProto(item))
+		}
+		resp.More = more
+		return resp, nil
+	}
+
+	items, err := s.store.ItemsSince(ctx, userID, req.GetSince().AsTime())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	for _, item := range items {
+		resp.Items = append(resp.Items, itemToProto(item))
+	}
+	return resp, nil
+}
+
+func itemToProto(item *models.Item) *pb.Item {
+	out := &pb.Item{
+		Id:        item.ID,
+		Name:      item.Name,
+		Type:      item.Type,
+		Data:      item.Data,
+		Metadata:  item.Metadata,
+		Tags:      item.Tags,
+		Version:   item.Version,
+		CreatedAt: timestamppb.New(item.CreatedAt),
+		UpdatedAt: timestamppb.New(item.UpdatedAt),
+	}
+	if !item.ExpiresAt.IsZero() {
+		out.ExpiresAt = timestamppb.New(item.ExpiresAt)
+	}
+	if !item.DeletedAt.IsZero() {
+		out.DeletedAt = timestamppb.New(item.DeletedAt)
+	}
+	return out
+}
+
+func itemFromProto(item *pb.Item) *models.Item {
+	out := &models.Item{
+		ID:       item.GetId(),
+		Name:     item.GetName(),
+		Type:     item.GetType(),
+		Data:     item.GetData(),
+		Metadata: item.GetMetadata(),
+		Tags:     item.GetTags(),
+		Version:  item.GetVersion(),
+	}
+	if item.GetExpiresAt() != nil {
+		out.ExpiresAt = item.GetExpiresAt().AsTime()
+	}
+	return out
+}
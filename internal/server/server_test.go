@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	"github.com/ar11/gophkeeper/internal/storage"
+	"github.com/ar11/gophkeeper/pkg/auth"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+var testSecret = []byte("test-secret")
+
+// newTestServer returns a server over an in-memory store plus the ID of
+// a freshly created user. Tests call handlers directly with an already
+// authenticated context; the interceptor has its own tests.
+func newTestServer(t *testing.T) (*Server, storage.Storage, int64) {
+	t.Helper()
+	store := storage.NewMemoryStorage()
+	srv := New(store, testSecret)
+	user, err := store.CreateUser(context.Background(), "tester", "hash")
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return srv, store, user.ID
+}
+
+// authedContext mimics what the auth interceptor injects for userID.
+func authedContext(userID int64) context.Context {
+	return contextWithClaims(context.Background(), &auth.Claims{UserID: userID})
+}
+
+func TestSyncConflictDetection(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+	ctx := authedContext(userID)
+
+	// Seed three items the client is assumed to have synced already.
+	ids := map[string]int64{}
+	for _, name := range []string{"clean", "server-wins", "conflict"} {
+		id, err := store.AddItem(context.Background(), &models.Item{
+			UserID: userID, Name: name, Type: models.TypeText, Data: []byte("v1"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[name] = id
+	}
+	baseline := time.Now()
+
+	// "server-wins" and "conflict" change server-side after the client's
+	// last sync; the client concurrently edits "clean" and "conflict"
+	// against base version 1.
+	for _, name := range []string{"server-wins", "conflict"} {
+		if err := store.UpdateItem(context.Background(), &models.Item{
+			ID: ids[name], UserID: userID, Name: name, Data: []byte("server-v2"),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := srv.Sync(ctx, &pb.SyncRequest{
+		Since: timestamppb.New(baseline),
+		LocalChanges: []*pb.Item{
+			{Id: ids["clean"], Name: "clean", Type: models.TypeText, Data: []byte("local-v2"), Version: 1},
+			{Id: ids["conflict"], Name: "conflict", Type: models.TypeText, Data: []byte("local-v2"), Version: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	// Clean update: the base version matched, so it was applied.
+	clean, err := store.GetItem(context.Background(), userID, ids["clean"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(clean.Data) != "local-v2" || clean.Version != 2 {
+		t.Fatalf("clean change not applied: %+v", clean)
+	}
+
+	// Genuine conflict: reported, and the server copy is untouched.
+	if len(resp.GetConflicts()) != 1 {
+		t.Fatalf("got %d conflicts, want 1", len(resp.GetConflicts()))
+	}
+	conflict := resp.GetConflicts()[0]
+	if conflict.GetServer().GetName() != "conflict" {
+		t.Fatalf("conflict reported for %q, want %q", conflict.GetServer().GetName(), "conflict")
+	}
+	if string(conflict.GetLocal().GetData()) != "local-v2" || string(conflict.GetServer().GetData()) != "server-v2" {
+		t.Fatal("conflict does not carry both versions")
+	}
+	stored, err := store.GetItem(context.Background(), userID, ids["conflict"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(stored.Data) != "server-v2" {
+		t.Fatalf("conflicting local change was applied over server data: %+v", stored)
+	}
+
+	// Server-wins: the purely server-side change comes back in Items.
+	names := map[string]bool{}
+	for _, item := range resp.GetItems() {
+		names[item.GetName()] = true
+	}
+	if !names["server-wins"] {
+		t.Fatalf("server-side change not returned, got %v", names)
+	}
+}
+
+func TestSyncCreatesNewLocalItems(t *testing.T) {
+	srv, store, userID := newTestServer(t)
+
+	resp, err := srv.Sync(authedContext(userID), &pb.SyncRequest{
+		LocalChanges: []*pb.Item{
+			{Name: "brand-new", Type: models.TypeText, Data: []byte("v1")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if len(resp.GetConflicts()) != 0 {
+		t.Fatalf("unexpected conflicts: %v", resp.GetConflicts())
+	}
+	item, err := store.GetItemByName(context.Background(), userID, "brand-new")
+	if err != nil {
+		t.Fatalf("new item not created: %v", err)
+	}
+	if string(item.Data) != "v1" {
+		t.Fatalf("new item stored wrong data: %+v", item)
+	}
+}
+
+func TestSyncRequiresAuth(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	if _, err := srv.Sync(context.Background(), &pb.SyncRequest{}); err == nil {
+		t.Fatal("sync without an authenticated context succeeded")
+	}
+}
+
+func TestSyncPaginatedPull(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	for i := 0; i < 5; i++ {
+		if _, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: &pb.Item{
+			Name: fmt.Sprintf("bulk-%d", i), Type: models.TypeText, Data: []byte("x"),
+		}}); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	req := &pb.SyncRequest{PageSize: 2}
+	seen := map[string]bool{}
+	pages := 0
+	for {
+		resp, err := srv.Sync(ctx, req)
+		if err != nil {
+			t.Fatalf("sync page %d: %v", pages, err)
+		}
+		pages++
+		for _, item := range resp.GetItems() {
+			if seen[item.GetName()] {
+				t.Fatalf("item %q served twice", item.GetName())
+			}
+			seen[item.GetName()] = true
+		}
+		if !resp.GetMore() {
+			break
+		}
+		last := resp.GetItems()[len(resp.GetItems())-1]
+		req.CursorUpdatedAt = last.GetUpdatedAt()
+		req.CursorId = last.GetId()
+	}
+	if len(seen) != 5 || pages != 3 {
+		t.Fatalf("paginated sync served %d items over %d pages, want 5 over 3", len(seen), pages)
+	}
+}
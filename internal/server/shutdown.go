@@ -0,0 +1,42 @@
+package server
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// defaultShutdownTimeout bounds how long a graceful shutdown waits for
+// in-flight requests before forcing a stop.
+const defaultShutdownTimeout = 30 * time.Second
+
+// InFlight returns the number of requests currently being handled, as
+// tracked by LoggingInterceptor.
+func (s *Server) InFlight() int64 {
+	return s.inFlight.Load()
+}
+
+// Shutdown drains grpcServer gracefully, falling back to a hard stop
+// once timeout elapses so a stuck stream cannot hang shutdown forever.
+func (s *Server) Shutdown(grpcServer *grpc.Server, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	draining := s.InFlight()
+	s.logger.Info("shutting down", "in_flight", draining)
+
+	done := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		s.logger.Info("graceful shutdown complete", "drained", draining)
+	case <-time.After(timeout):
+		// Stop severs the remaining connections; the GracefulStop
+		// goroutine is left to unwind on its own.
+		s.logger.Warn("shutdown timeout elapsed, forcing stop", "still_in_flight", s.InFlight())
+		grpcServer.Stop()
+	}
+}
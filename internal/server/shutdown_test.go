@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+)
+
+// stuckStore blocks Ping until the test releases it, simulating a
+// handler that never finishes.
+type stuckStore struct {
+	*storage.MemoryStorage
+	release chan struct{}
+}
+
+func (s *stuckStore) Ping(ctx context.Context) error {
+	<-s.release
+	return nil
+}
+
+func TestShutdownForcesStopAfterTimeout(t *testing.T) {
+	store := &stuckStore{MemoryStorage: storage.NewMemoryStorage(), release: make(chan struct{})}
+	defer close(store.release)
+	srv := New(store, []byte("test-secret"))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, NewHealthServer(store))
+	go grpcServer.Serve(listener)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Park a request inside the stuck handler so GracefulStop alone
+	// would never return.
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	}()
+	<-started
+	time.Sleep(100 * time.Millisecond)
+
+	begin := time.Now()
+	srv.Shutdown(grpcServer, 300*time.Millisecond)
+	if elapsed := time.Since(begin); elapsed > 3*time.Second {
+		t.Fatalf("shutdown took %v, want roughly the 300ms timeout", elapsed)
+	}
+}
+
+func TestShutdownDrainsIdleServerImmediately(t *testing.T) {
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	go grpcServer.Serve(listener)
+
+	begin := time.Now()
+	srv.Shutdown(grpcServer, 30*time.Second)
+	if elapsed := time.Since(begin); elapsed > 5*time.Second {
+		t.Fatalf("idle shutdown took %v, want immediate", elapsed)
+	}
+}
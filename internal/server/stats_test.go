@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestStatsSummarizesVault(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	add := func(name, typ string, size int) {
+		t.Helper()
+		if _, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: &pb.Item{
+			Name: name, Type: typ, Data: make([]byte, size),
+		}}); err != nil {
+			t.Fatalf("add %s: %v", name, err)
+		}
+	}
+	add("note-1", models.TypeText, 10)
+	add("note-2", models.TypeText, 20)
+	add("card-1", models.TypeCard, 5)
+
+	resp, err := srv.Stats(ctx, &pb.StatsRequest{})
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if len(resp.GetTypes()) != 2 {
+		t.Fatalf("got %d types, want 2", len(resp.GetTypes()))
+	}
+	// Sorted by type name: card before text.
+	card, text := resp.GetTypes()[0], resp.GetTypes()[1]
+	if card.GetType() != models.TypeCard || card.GetCount() != 1 || card.GetTotalSize() != 5 {
+		t.Fatalf("card stats = %+v", card)
+	}
+	if text.GetType() != models.TypeText || text.GetCount() != 2 || text.GetTotalSize() != 30 {
+		t.Fatalf("text stats = %+v", text)
+	}
+	if !text.GetNewest().AsTime().After(text.GetOldest().AsTime()) &&
+		!text.GetNewest().AsTime().Equal(text.GetOldest().AsTime()) {
+		t.Fatalf("text timestamps out of order: %v / %v", text.GetOldest(), text.GetNewest())
+	}
+}
+
+func TestStatsEmptyVault(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	resp, err := srv.Stats(authedContext(userID), &pb.StatsRequest{})
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if len(resp.GetTypes()) != 0 {
+		t.Fatalf("empty vault reports %v", resp.GetTypes())
+	}
+}
+
+func TestStatsRequiresAuth(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+	if _, err := srv.Stats(context.Background(), &pb.StatsRequest{}); err == nil {
+		t.Fatal("stats without auth succeeded")
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// requestLimiter keeps one token bucket per caller, throttling overall
+// request volume. It is separate from loginLimiter, which only counts
+// failed login attempts.
+type requestLimiter struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	buckets map[string]*rate.Limiter
+}
+
+func newRequestLimiter(perSecond float64, burst int) *requestLimiter {
+	return &requestLimiter{
+		limit:   rate.Limit(perSecond),
+		burst:   burst,
+		buckets: map[string]*rate.Limiter{},
+	}
+}
+
+// allow reports whether key may make another request right now.
+func (l *requestLimiter) allow(key string) bool {
+	l.mu.Lock()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(l.limit, l.burst)
+		l.buckets[key] = bucket
+	}
+	l.mu.Unlock()
+	return bucket.Allow()
+}
+
+// SetRequestRateLimit enables per-caller request throttling: each
+// authenticated user (or, for unauthenticated RPCs, each client IP) may
+// make perSecond sustained requests with bursts up to burst.
+func (s *Server) SetRequestRateLimit(perSecond float64, burst int) {
+	s.requestLimiter = newRequestLimiter(perSecond, burst)
+}
+
+// RateLimitInterceptor rejects requests over the configured rate with
+// ResourceExhausted. With no configured limit it passes everything
+// through. It must run after AuthInterceptor so authenticated calls are
+// keyed by user ID; unauthenticated ones fall back to the peer IP.
+func (s *Server) RateLimitInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if s.requestLimiter == nil {
+			return handler(ctx, req)
+		}
+		if !s.requestLimiter.allow(rateLimitKey(ctx)) {
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded, slow down")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitKey identifies the caller: the authenticated user if claims
+// are present, the peer IP otherwise.
+func rateLimitKey(ctx context.Context) string {
+	if claims, err := claimsFromContext(ctx); err == nil {
+		return "user:" + strconv.FormatInt(claims.UserID, 10)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "ip:" + host
+	}
+	return "unknown"
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/storage"
+	"github.com/ar11/gophkeeper/pkg/auth"
+)
+
+func TestRateLimitInterceptorRejectsBursts(t *testing.T) {
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	srv.SetRequestRateLimit(5, 2)
+
+	interceptor := srv.RateLimitInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/ListItems"}
+	ok := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	ctx := contextWithClaims(context.Background(), &auth.Claims{UserID: 7})
+
+	for i := 0; i < 2; i++ {
+		if _, err := interceptor(ctx, nil, info, ok); err != nil {
+			t.Fatalf("request %d within the burst rejected: %v", i+1, err)
+		}
+	}
+	if _, err := interceptor(ctx, nil, info, ok); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("request above the burst = %v, want ResourceExhausted", err)
+	}
+
+	// Another user has their own bucket.
+	other := contextWithClaims(context.Background(), &auth.Claims{UserID: 8})
+	if _, err := interceptor(other, nil, info, ok); err != nil {
+		t.Fatalf("unrelated user throttled: %v", err)
+	}
+
+	// At 5 req/s the bucket regains a token after 200ms.
+	time.Sleep(250 * time.Millisecond)
+	if _, err := interceptor(ctx, nil, info, ok); err != nil {
+		t.Fatalf("bucket did not refill: %v", err)
+	}
+}
+
+func TestRateLimitInterceptorKeysUnauthenticatedByIP(t *testing.T) {
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	srv.SetRequestRateLimit(1, 1)
+
+	interceptor := srv.RateLimitInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/Login"}
+	ok := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	fromIP := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 12345},
+		})
+	}
+
+	if _, err := interceptor(fromIP("192.0.2.1"), nil, info, ok); err != nil {
+		t.Fatalf("first request rejected: %v", err)
+	}
+	if _, err := interceptor(fromIP("192.0.2.1"), nil, info, ok); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second request from the same IP = %v, want ResourceExhausted", err)
+	}
+	if _, err := interceptor(fromIP("192.0.2.2"), nil, info, ok); err != nil {
+		t.Fatalf("different IP throttled: %v", err)
+	}
+}
+
+func TestRateLimitInterceptorDisabledByDefault(t *testing.T) {
+	srv := New(storage.NewMemoryStorage(), []byte("test-secret"))
+	interceptor := srv.RateLimitInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/gophkeeper.GophKeeper/ListItems"}
+	ok := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	for i := 0; i < 100; i++ {
+		if _, err := interceptor(context.Background(), nil, info, ok); err != nil {
+			t.Fatalf("unconfigured limiter rejected request %d: %v", i, err)
+		}
+	}
+}
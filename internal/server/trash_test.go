@@ -0,0 +1,87 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestTrashRestoreRoundTrip(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	added, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: &pb.Item{
+		Name: "oops", Type: models.TypeText, Data: []byte("x"),
+	}})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := srv.DeleteItem(ctx, &pb.DeleteItemRequest{Name: "oops"}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	list, err := srv.ListItems(ctx, &pb.ListItemsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.GetTotal() != 0 {
+		t.Fatalf("deleted item still listed: total %d", list.GetTotal())
+	}
+
+	trash, err := srv.ListTrash(ctx, &pb.ListTrashRequest{})
+	if err != nil {
+		t.Fatalf("list trash: %v", err)
+	}
+	if len(trash.GetItems()) != 1 {
+		t.Fatalf("trash holds %d items, want 1", len(trash.GetItems()))
+	}
+	got := trash.GetItems()[0]
+	if got.GetId() != added.GetId() || got.GetName() != "oops" || got.GetDeletedAt() == nil {
+		t.Fatalf("trash entry = %+v", got)
+	}
+
+	restored, err := srv.RestoreItem(ctx, &pb.RestoreItemRequest{Id: added.GetId()})
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	// Version 1 at add, 2 at delete, 3 at restore.
+	if restored.GetItem().GetVersion() != 3 || restored.GetItem().GetDeletedAt() != nil {
+		t.Fatalf("restored item = %+v", restored.GetItem())
+	}
+
+	list, err = srv.ListItems(ctx, &pb.ListItemsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.GetTotal() != 1 || list.GetItems()[0].GetName() != "oops" {
+		t.Fatalf("restored item missing from list: %+v", list)
+	}
+	trash, err = srv.ListTrash(ctx, &pb.ListTrashRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trash.GetItems()) != 0 {
+		t.Fatal("restored item still in the trash")
+	}
+}
+
+func TestRestoreItemErrors(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	if _, err := srv.RestoreItem(ctx, &pb.RestoreItemRequest{}); err == nil {
+		t.Fatal("restore without an id succeeded")
+	}
+	if _, err := srv.RestoreItem(ctx, &pb.RestoreItemRequest{Id: 42}); err == nil {
+		t.Fatal("restoring an id not in the trash succeeded")
+	}
+	// A live item cannot be "restored" either.
+	added, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: &pb.Item{
+		Name: "alive", Type: models.TypeText, Data: []byte("x"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.RestoreItem(ctx, &pb.RestoreItemRequest{Id: added.GetId()}); err == nil {
+		t.Fatal("restoring a live item succeeded")
+	}
+}
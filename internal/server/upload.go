@@ -0,0 +1,70 @@
+package server
+
+import (
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+// defaultMaxUploadSize caps the total encrypted size of a streamed
+// upload when the operator does not configure a limit.
+const defaultMaxUploadSize = 64 << 20
+
+// SetMaxUploadSize overrides the total size allowed for a streamed
+// upload.
+func (s *Server) SetMaxUploadSize(limit int64) {
+	s.maxUploadSize = limit
+}
+
+// UploadItem stores an item streamed up by the client: metadata in the
+// first message, encrypted data chunks after. The item is buffered and
+// only written to storage once the stream completes cleanly, so an
+// aborted or oversized upload leaves nothing behind.
+func (s *Server) UploadItem(stream pb.GophKeeper_UploadItemServer) error {
+	ctx := stream.Context()
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return grpcError(err)
+	}
+	if first.GetItem() == nil {
+		return status.Error(codes.InvalidArgument, "first upload message must carry the item metadata")
+	}
+	item := itemFromProto(first.GetItem())
+	item.UserID = userID
+	// The same name/type/metadata checks as a unary AddItem; the
+	// payload size is governed by the upload cap below instead.
+	if err := s.validateItemMeta(item); err != nil {
+		return err
+	}
+
+	data := first.GetData()
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return grpcError(err)
+		}
+		data = append(data, chunk.GetData()...)
+		if int64(len(data)) > s.maxUploadSize {
+			return status.Errorf(codes.ResourceExhausted, "upload exceeds the %d byte limit", s.maxUploadSize)
+		}
+	}
+	item.Data = data
+
+	id, err := s.store.AddItem(ctx, item)
+	if err != nil {
+		return grpcError(err)
+	}
+	s.audit(ctx, userID, auditActionAdd, id)
+	return stream.SendAndClose(&pb.UploadItemResponse{Id: id})
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ar11/gophkeeper/internal/models"
+	pb "github.com/ar11/gophkeeper/proto"
+)
+
+func TestAddItemValidation(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	srv.SetMaxItemSize(1024)
+
+	if _, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: &pb.Item{
+		Name: "baseline", Type: models.TypeText, Data: []byte("ok"),
+	}}); err != nil {
+		t.Fatalf("valid item rejected: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		item *pb.Item
+	}{
+		{"blank name", &pb.Item{Name: "   ", Type: models.TypeText, Data: []byte("x")}},
+		{"overlong name", &pb.Item{Name: strings.Repeat("a", models.MaxNameLength+1), Type: models.TypeText, Data: []byte("x")}},
+		{"unspecified type", &pb.Item{Name: "no-type", Data: []byte("x")}},
+		{"unknown type", &pb.Item{Name: "bad-type", Type: "carrier_pigeon", Data: []byte("x")}},
+		{"oversized data", &pb.Item{Name: "too-big", Type: models.TypeBinary, Data: make([]byte, 2048)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.AddItem(ctx, &pb.AddItemRequest{Item: tt.item})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("got %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestBatchAddItemsValidation(t *testing.T) {
+	srv, _, userID := newTestServer(t)
+	ctx := authedContext(userID)
+	srv.SetMaxItemSize(1024)
+
+	// Items a unary AddItem would reject must not slip in via the batch
+	// path either.
+	resp, err := srv.BatchAddItems(ctx, &pb.BatchAddItemsRequest{Items: []*pb.Item{
+		{Name: "good", Type: models.TypeText, Data: []byte("x")},
+		{Name: "bad-type", Type: "carrier_pigeon", Data: []byte("x")},
+		{Name: "too-big", Type: models.TypeBinary, Data: make([]byte, 2048)},
+		{Name: strings.Repeat("a", models.MaxNameLength+1), Type: models.TypeText, Data: []byte("x")},
+	}})
+	if err != nil {
+		t.Fatalf("batch add: %v", err)
+	}
+	if resp.GetApplied() {
+		t.Fatal("batch with invalid items reported as applied")
+	}
+	results := resp.GetResults()
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4", len(results))
+	}
+	if results[0].GetError() != "" {
+		t.Fatalf("valid item blamed: %+v", results[0])
+	}
+	for _, result := range results[1:] {
+		if result.GetError() == "" {
+			t.Fatalf("invalid item %q accepted", result.GetName())
+		}
+	}
+}
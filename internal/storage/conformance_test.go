@@ -0,0 +1,1162 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// runStorageTests is the backend-independent Storage test suite. Both
+// implementations must pass it unchanged.
+func runStorageTests(t *testing.T, open func(t *testing.T) Storage) {
+	t.Run("Users", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+
+		user, err := s.CreateUser(context.Background(), "alice", "hash-1")
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		if user.ID == 0 {
+			t.Fatal("user ID not assigned")
+		}
+
+		if _, err := s.CreateUser(context.Background(), "alice", "hash-2"); !errors.Is(err, ErrUserExists) {
+			t.Fatalf("duplicate login: got %v, want ErrUserExists", err)
+		}
+
+		got, err := s.GetUserByLogin(context.Background(), "alice")
+		if err != nil {
+			t.Fatalf("get user: %v", err)
+		}
+		if got.ID != user.ID || got.PasswordHash != "hash-1" {
+			t.Fatalf("got user %+v, want id %d hash-1", got, user.ID)
+		}
+
+		if _, err := s.GetUserByLogin(context.Background(), "nobody"); !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("missing login: got %v, want ErrUserNotFound", err)
+		}
+
+		// The last login is zero until recorded, then round-trips.
+		if !got.LastLoginAt.IsZero() || got.LastLoginIP != "" {
+			t.Fatalf("fresh user has a last login: %+v", got)
+		}
+		if err := s.UpdateUserLastLogin(context.Background(), user.ID, "192.0.2.1"); err != nil {
+			t.Fatalf("update last login: %v", err)
+		}
+		got, err = s.GetUserByLogin(context.Background(), "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.LastLoginAt.IsZero() || got.LastLoginIP != "192.0.2.1" {
+			t.Fatalf("last login not recorded: %+v", got)
+		}
+		if err := s.UpdateUserLastLogin(context.Background(), user.ID+999, "192.0.2.1"); !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("unknown user: got %v, want ErrUserNotFound", err)
+		}
+	})
+
+	t.Run("DeleteUser", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		victim := mustCreateUser(t, s, "victim")
+		bystander := mustCreateUser(t, s, "bystander")
+
+		if _, err := s.AddItem(context.Background(), &models.Item{
+			UserID: victim.ID, Name: "doomed", Type: models.TypeText, Data: []byte("x"),
+		}); err != nil {
+			t.Fatalf("add item: %v", err)
+		}
+		keptID, err := s.AddItem(context.Background(), &models.Item{
+			UserID: bystander.ID, Name: "kept", Type: models.TypeText, Data: []byte("y"),
+		})
+		if err != nil {
+			t.Fatalf("add item: %v", err)
+		}
+		if err := s.CreateRefreshToken(context.Background(), victim.ID, "victim-refresh", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("create refresh token: %v", err)
+		}
+
+		if err := s.DeleteUser(context.Background(), victim.ID); err != nil {
+			t.Fatalf("delete user: %v", err)
+		}
+		if _, err := s.GetUserByID(context.Background(), victim.ID); !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("deleted user lookup = %v, want ErrUserNotFound", err)
+		}
+		if _, err := s.GetItemByName(context.Background(), victim.ID, "doomed"); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("deleted user's item = %v, want ErrItemNotFound", err)
+		}
+		if _, err := s.GetRefreshToken(context.Background(), "victim-refresh"); !errors.Is(err, ErrRefreshTokenNotFound) {
+			t.Fatalf("deleted user's refresh token = %v, want ErrRefreshTokenNotFound", err)
+		}
+		if err := s.DeleteUser(context.Background(), victim.ID); !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("second delete = %v, want ErrUserNotFound", err)
+		}
+
+		// The other account is untouched.
+		if _, err := s.GetItem(context.Background(), bystander.ID, keptID); err != nil {
+			t.Fatalf("bystander's item: %v", err)
+		}
+	})
+
+	t.Run("RefreshTokens", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "refresher")
+
+		expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		if err := s.CreateRefreshToken(context.Background(), user.ID, "tok-1", expires); err != nil {
+			t.Fatalf("create refresh token: %v", err)
+		}
+
+		rt, err := s.GetRefreshToken(context.Background(), "tok-1")
+		if err != nil {
+			t.Fatalf("get refresh token: %v", err)
+		}
+		if rt.UserID != user.ID || !rt.ExpiresAt.UTC().Truncate(time.Second).Equal(expires) {
+			t.Fatalf("got %+v, want user %d expiring %v", rt, user.ID, expires)
+		}
+
+		if err := s.DeleteRefreshToken(context.Background(), "tok-1"); err != nil {
+			t.Fatalf("delete refresh token: %v", err)
+		}
+		if _, err := s.GetRefreshToken(context.Background(), "tok-1"); !errors.Is(err, ErrRefreshTokenNotFound) {
+			t.Fatalf("revoked token: got %v, want ErrRefreshTokenNotFound", err)
+		}
+
+		// Deleting an unknown token is not an error.
+		if err := s.DeleteRefreshToken(context.Background(), "never-issued"); err != nil {
+			t.Fatalf("delete unknown token: %v", err)
+		}
+	})
+
+	t.Run("ItemCRUD", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "bob")
+
+		meta := map[string]string{"site": "example.com", "note": "work"}
+		id, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "mail", Type: models.TypeLoginPassword,
+			Data: []byte("encrypted"), Metadata: meta,
+		})
+		if err != nil {
+			t.Fatalf("add item: %v", err)
+		}
+
+		item, err := s.GetItem(context.Background(), user.ID, id)
+		if err != nil {
+			t.Fatalf("get item: %v", err)
+		}
+		if item.Name != "mail" || item.Version != 1 {
+			t.Fatalf("got item %+v, want name mail version 1", item)
+		}
+		if !reflect.DeepEqual(item.Metadata, meta) {
+			t.Fatalf("metadata did not survive: got %v, want %v", item.Metadata, meta)
+		}
+
+		if _, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "mail", Type: models.TypeText, Data: []byte("y"),
+		}); !errors.Is(err, ErrItemExists) {
+			t.Fatalf("duplicate name: got %v, want ErrItemExists", err)
+		}
+
+		byName, err := s.GetItemByName(context.Background(), user.ID, "mail")
+		if err != nil {
+			t.Fatalf("get item by name: %v", err)
+		}
+		if byName.ID != id {
+			t.Fatalf("get by name returned id %d, want %d", byName.ID, id)
+		}
+
+		item.Data = []byte("encrypted-v2")
+		if err := s.UpdateItem(context.Background(), item); err != nil {
+			t.Fatalf("update item: %v", err)
+		}
+		updated, err := s.GetItem(context.Background(), user.ID, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Version != 2 || string(updated.Data) != "encrypted-v2" {
+			t.Fatalf("update not applied: %+v", updated)
+		}
+
+		if err := s.DeleteItem(context.Background(), user.ID, id); err != nil {
+			t.Fatalf("delete item: %v", err)
+		}
+		if _, err := s.GetItem(context.Background(), user.ID, id); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("deleted item: got %v, want ErrItemNotFound", err)
+		}
+		if err := s.DeleteItem(context.Background(), user.ID, id); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("double delete: got %v, want ErrItemNotFound", err)
+		}
+		if err := s.DeleteItem(context.Background(), user.ID, 99999); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("delete unknown id: got %v, want ErrItemNotFound", err)
+		}
+		updated.Data = []byte("too late")
+		updated.Version = 0
+		if err := s.UpdateItem(context.Background(), updated); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("update deleted item: got %v, want ErrItemNotFound", err)
+		}
+	})
+
+	t.Run("BatchAdd", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "batcher")
+
+		ids, err := s.AddItems(context.Background(), []*models.Item{
+			{UserID: user.ID, Name: "one", Type: models.TypeText, Data: []byte("1")},
+			{UserID: user.ID, Name: "two", Type: models.TypeText, Data: []byte("2")},
+		})
+		if err != nil {
+			t.Fatalf("add items: %v", err)
+		}
+		if len(ids) != 2 {
+			t.Fatalf("got %d ids, want 2", len(ids))
+		}
+		for _, name := range []string{"one", "two"} {
+			if _, err := s.GetItemByName(context.Background(), user.ID, name); err != nil {
+				t.Fatalf("batch item %q not stored: %v", name, err)
+			}
+		}
+
+		// A collision with a stored item fails the whole batch: "three"
+		// must be rolled back along with the duplicate.
+		_, err = s.AddItems(context.Background(), []*models.Item{
+			{UserID: user.ID, Name: "three", Type: models.TypeText, Data: []byte("3")},
+			{UserID: user.ID, Name: "one", Type: models.TypeText, Data: []byte("dup")},
+		})
+		if err == nil {
+			t.Fatal("conflicting batch succeeded")
+		}
+		if _, err := s.GetItemByName(context.Background(), user.ID, "three"); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("batch not rolled back, got %v for item before the conflict", err)
+		}
+		if kept, err := s.GetItemByName(context.Background(), user.ID, "one"); err != nil || string(kept.Data) != "1" {
+			t.Fatalf("existing item damaged by failed batch: %v %+v", err, kept)
+		}
+	})
+
+	t.Run("SoftDeletePurge", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "reaper")
+
+		oldID, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "old", Type: models.TypeText, Data: []byte("x"),
+		})
+		if err != nil {
+			t.Fatalf("add item: %v", err)
+		}
+		liveID, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "live", Type: models.TypeText, Data: []byte("y"),
+		})
+		if err != nil {
+			t.Fatalf("add item: %v", err)
+		}
+
+		if err := s.DeleteItem(context.Background(), user.ID, oldID); err != nil {
+			t.Fatalf("delete item: %v", err)
+		}
+		// The soft-deleted item disappears from reads but survives as a
+		// sync tombstone with a bumped version.
+		if _, err := s.GetItem(context.Background(), user.ID, oldID); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("soft-deleted item: got %v, want ErrItemNotFound", err)
+		}
+		changes, err := s.ItemsSince(context.Background(), user.ID, time.Time{})
+		if err != nil {
+			t.Fatalf("items since: %v", err)
+		}
+		var tombstone *models.Item
+		for _, item := range changes {
+			if item.ID == oldID {
+				tombstone = item
+			}
+		}
+		if tombstone == nil {
+			t.Fatal("soft-deleted item missing from ItemsSince")
+		}
+		if tombstone.DeletedAt.IsZero() || tombstone.Version != 2 {
+			t.Fatalf("tombstone = %+v, want DeletedAt set and version 2", tombstone)
+		}
+
+		// A cutoff before the deletion leaves the tombstone in place.
+		removed, err := s.PurgeDeleted(context.Background(), user.ID, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("purge deleted: %v", err)
+		}
+		if removed != 0 {
+			t.Fatalf("purged %d items with an old cutoff, want 0", removed)
+		}
+
+		removed, err = s.PurgeDeleted(context.Background(), user.ID, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("purge deleted: %v", err)
+		}
+		if removed != 1 {
+			t.Fatalf("purged %d items, want 1", removed)
+		}
+		changes, err = s.ItemsSince(context.Background(), user.ID, time.Time{})
+		if err != nil {
+			t.Fatalf("items since: %v", err)
+		}
+		for _, item := range changes {
+			if item.ID == oldID {
+				t.Fatalf("purged item still in ItemsSince: %+v", item)
+			}
+		}
+		if _, err := s.GetItem(context.Background(), user.ID, liveID); err != nil {
+			t.Fatalf("live item was purged: %v", err)
+		}
+
+		// A soft-deleted name can be reused right away.
+		if err := s.DeleteItem(context.Background(), user.ID, liveID); err != nil {
+			t.Fatalf("delete item: %v", err)
+		}
+		if _, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "live", Type: models.TypeText, Data: []byte("z"),
+		}); err != nil {
+			t.Fatalf("re-add of soft-deleted name: %v", err)
+		}
+
+		// PurgeItem skips the retention entirely, even for live items.
+		if err := s.PurgeItem(context.Background(), user.ID, 0, "live"); err != nil {
+			t.Fatalf("purge item: %v", err)
+		}
+		if _, err := s.GetItemByName(context.Background(), user.ID, "live"); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("purged item: got %v, want ErrItemNotFound", err)
+		}
+		if err := s.PurgeItem(context.Background(), user.ID, 0, "live"); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("purge of missing item: got %v, want ErrItemNotFound", err)
+		}
+	})
+
+	t.Run("UpdateByName", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "renamer")
+
+		if _, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "note", Type: models.TypeText, Data: []byte("v1"),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Clean update carrying the current base version.
+		if err := s.UpdateItemByName(context.Background(), &models.Item{
+			UserID: user.ID, Name: "note", Data: []byte("v2"), Version: 1,
+		}); err != nil {
+			t.Fatalf("update by name: %v", err)
+		}
+		updated, err := s.GetItemByName(context.Background(), user.ID, "note")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Version != 2 || string(updated.Data) != "v2" {
+			t.Fatalf("update not applied: %+v", updated)
+		}
+
+		// A stale base version means someone updated concurrently.
+		err = s.UpdateItemByName(context.Background(), &models.Item{
+			UserID: user.ID, Name: "note", Data: []byte("stale"), Version: 1,
+		})
+		if !errors.Is(err, ErrVersionConflict) {
+			t.Fatalf("stale update: got %v, want ErrVersionConflict", err)
+		}
+
+		err = s.UpdateItemByName(context.Background(), &models.Item{
+			UserID: user.ID, Name: "missing", Data: []byte("x"),
+		})
+		if !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("unknown name: got %v, want ErrItemNotFound", err)
+		}
+	})
+
+	t.Run("VersionHistory", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "historian")
+
+		id, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "pw", Type: models.TypeText, Data: []byte("v1"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, data := range []string{"v2", "v3"} {
+			if err := s.UpdateItem(context.Background(), &models.Item{UserID: user.ID, ID: id, Data: []byte(data)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		versions, err := s.ListItemVersions(context.Background(), user.ID, id)
+		if err != nil {
+			t.Fatalf("list item versions: %v", err)
+		}
+		if len(versions) != 2 {
+			t.Fatalf("got %d archived versions, want 2", len(versions))
+		}
+		if versions[0].Version != 2 || string(versions[0].Data) != "v2" {
+			t.Fatalf("newest archived version = %d %q, want 2 \"v2\"", versions[0].Version, versions[0].Data)
+		}
+		if versions[1].Version != 1 || string(versions[1].Data) != "v1" {
+			t.Fatalf("oldest archived version = %d %q, want 1 \"v1\"", versions[1].Version, versions[1].Data)
+		}
+
+		// Another user cannot read the history.
+		other := mustCreateUser(t, s, "historian2")
+		versions, err = s.ListItemVersions(context.Background(), other.ID, id)
+		if err != nil || len(versions) != 0 {
+			t.Fatalf("foreign history: got %d versions, err %v", len(versions), err)
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "carol")
+
+		for i := 0; i < 5; i++ {
+			_, err := s.AddItem(context.Background(), &models.Item{
+				UserID: user.ID, Name: fmt.Sprintf("item-%d", i),
+				Type: models.TypeText, Data: []byte("x"),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		seen := map[int64]bool{}
+		for offset := int64(0); offset < 5; offset += 2 {
+			items, total, err := s.ListItems(context.Background(), user.ID, 2, offset, nil, nil, "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != 5 {
+				t.Fatalf("total = %d, want 5", total)
+			}
+			for _, item := range items {
+				if seen[item.ID] {
+					t.Fatalf("item %d returned on two pages", item.ID)
+				}
+				seen[item.ID] = true
+			}
+		}
+		if len(seen) != 5 {
+			t.Fatalf("pagination covered %d items, want 5", len(seen))
+		}
+
+		items, _, err := s.ListItems(context.Background(), user.ID, 2, 100, nil, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 0 {
+			t.Fatalf("expected empty page past the end, got %d items", len(items))
+		}
+
+		all, _, err := s.ListItems(context.Background(), user.ID, 0, 0, nil, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != 5 {
+			t.Fatalf("unlimited list returned %d items, want 5", len(all))
+		}
+	})
+
+	t.Run("ListItemsSort", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "sorter")
+		for _, it := range []struct{ name, typ string }{
+			{"banana", models.TypeText},
+			{"apple", models.TypeCard},
+			{"cherry", models.TypeBinary},
+		} {
+			if _, err := s.AddItem(context.Background(), &models.Item{
+				UserID: user.ID, Name: it.name, Type: it.typ, Data: []byte("x"),
+			}); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(5 * time.Millisecond) // distinct timestamps on coarse clocks
+		}
+		names := func(items []*models.Item) []string {
+			out := make([]string, len(items))
+			for i, item := range items {
+				out[i] = item.Name
+			}
+			return out
+		}
+		list := func(sortBy, order string) []*models.Item {
+			t.Helper()
+			items, _, err := s.ListItems(context.Background(), user.ID, 0, 0, nil, nil, sortBy, order)
+			if err != nil {
+				t.Fatalf("list sorted by %s %s: %v", sortBy, order, err)
+			}
+			return items
+		}
+
+		if got := names(list(SortByName, OrderAsc)); !reflect.DeepEqual(got, []string{"apple", "banana", "cherry"}) {
+			t.Fatalf("sort by name asc: %v", got)
+		}
+		if got := names(list(SortByName, OrderDesc)); !reflect.DeepEqual(got, []string{"cherry", "banana", "apple"}) {
+			t.Fatalf("sort by name desc: %v", got)
+		}
+		// Types sort as binary < card < text.
+		if got := names(list(SortByType, OrderAsc)); !reflect.DeepEqual(got, []string{"cherry", "apple", "banana"}) {
+			t.Fatalf("sort by type asc: %v", got)
+		}
+		if got := names(list(SortByCreated, OrderDesc)); !reflect.DeepEqual(got, []string{"cherry", "apple", "banana"}) {
+			t.Fatalf("sort by created desc: %v", got)
+		}
+
+		// Touch the oldest item; it must come first by updated desc.
+		stored, err := s.GetItemByName(context.Background(), user.ID, "banana")
+		if err != nil {
+			t.Fatal(err)
+		}
+		stored.Data = []byte("y")
+		if err := s.UpdateItemByName(context.Background(), stored); err != nil {
+			t.Fatal(err)
+		}
+		if got := names(list(SortByUpdated, OrderDesc)); got[0] != "banana" {
+			t.Fatalf("sort by updated desc: %v", got)
+		}
+
+		// Anything outside the whitelist is rejected, never interpolated.
+		if _, _, err := s.ListItems(context.Background(), user.ID, 0, 0, nil, nil, "name; DROP TABLE items", ""); err == nil {
+			t.Fatal("sort field outside the whitelist accepted")
+		}
+		if _, _, err := s.ListItems(context.Background(), user.ID, 0, 0, nil, nil, SortByName, "sideways"); err == nil {
+			t.Fatal("unknown sort order accepted")
+		}
+	})
+
+	t.Run("TrashRestore", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "regretful")
+		id, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "oops", Type: models.TypeText, Data: []byte("x"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.DeleteItem(context.Background(), user.ID, id); err != nil {
+			t.Fatal(err)
+		}
+
+		trash, err := s.ListTrash(context.Background(), user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trash) != 1 || trash[0].ID != id || trash[0].DeletedAt.IsZero() {
+			t.Fatalf("trash = %+v, want the deleted item", trash)
+		}
+		// Another user's trash stays empty.
+		if other, err := s.ListTrash(context.Background(), user.ID+1); err != nil || len(other) != 0 {
+			t.Fatalf("other user's trash = %v, %v", other, err)
+		}
+
+		if err := s.RestoreItem(context.Background(), user.ID, id); err != nil {
+			t.Fatal(err)
+		}
+		restored, err := s.GetItem(context.Background(), user.ID, id)
+		if err != nil {
+			t.Fatalf("restored item unreadable: %v", err)
+		}
+		// Version 1 at add, 2 at delete, 3 at restore.
+		if restored.Version != 3 || !restored.DeletedAt.IsZero() {
+			t.Fatalf("restored item = %+v", restored)
+		}
+		if trash, err = s.ListTrash(context.Background(), user.ID); err != nil || len(trash) != 0 {
+			t.Fatalf("trash after restore = %v, %v", trash, err)
+		}
+
+		// Restoring a live or unknown item fails.
+		if err := s.RestoreItem(context.Background(), user.ID, id); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("restore live item = %v, want ErrItemNotFound", err)
+		}
+		if err := s.RestoreItem(context.Background(), user.ID, 9999); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("restore unknown id = %v, want ErrItemNotFound", err)
+		}
+	})
+
+	t.Run("CountItemsByType", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "counter")
+		other := mustCreateUser(t, s, "counter-other")
+		add := func(userID int64, name, typ string, size int) {
+			t.Helper()
+			if _, err := s.AddItem(context.Background(), &models.Item{
+				UserID: userID, Name: name, Type: typ, Data: make([]byte, size),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		add(user.ID, "a", models.TypeText, 10)
+		add(user.ID, "b", models.TypeText, 30)
+		add(user.ID, "c", models.TypeCard, 5)
+		add(other.ID, "theirs", models.TypeText, 99)
+
+		stats, err := s.CountItemsByType(context.Background(), user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stats) != 2 {
+			t.Fatalf("got stats for %d types, want 2: %v", len(stats), stats)
+		}
+		text := stats[models.TypeText]
+		if text.Count != 2 || text.TotalSize != 40 {
+			t.Fatalf("text stats = %+v, want 2 items of 40 bytes", text)
+		}
+		if text.Oldest.IsZero() || text.Newest.Before(text.Oldest) {
+			t.Fatalf("text stats carry bad timestamps: %+v", text)
+		}
+		card := stats[models.TypeCard]
+		if card.Count != 1 || card.TotalSize != 5 {
+			t.Fatalf("card stats = %+v, want 1 item of 5 bytes", card)
+		}
+
+		empty, err := s.CountItemsByType(context.Background(), 9999)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(empty) != 0 {
+			t.Fatalf("unknown user has stats: %v", empty)
+		}
+	})
+
+	t.Run("MetadataFilter", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "erin")
+
+		add := func(name string, meta map[string]string) {
+			t.Helper()
+			if _, err := s.AddItem(context.Background(), &models.Item{
+				UserID: user.ID, Name: name, Type: models.TypeText,
+				Data: []byte("x"), Metadata: meta,
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		add("gh-work", map[string]string{"website": "github.com", "env": "work"})
+		add("gh-home", map[string]string{"website": "github.com", "env": "home"})
+		add("mail", map[string]string{"website": "example.com"})
+
+		items, total, err := s.ListItems(context.Background(), user.ID, 0, 0, map[string]string{"website": "github.com"}, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 2 || len(items) != 2 {
+			t.Fatalf("single-key filter matched %d items (total %d), want 2", len(items), total)
+		}
+
+		items, total, err = s.ListItems(context.Background(), user.ID, 0, 0, map[string]string{"website": "github.com", "env": "work"}, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 || len(items) != 1 || items[0].Name != "gh-work" {
+			t.Fatalf("multi-key filter got %v (total %d), want just gh-work", items, total)
+		}
+
+		items, total, err = s.ListItems(context.Background(), user.ID, 0, 0, map[string]string{"website": "nowhere.test"}, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 0 || len(items) != 0 {
+			t.Fatalf("no-match filter returned %d items", len(items))
+		}
+
+		// Keys containing JSON path syntax are plain keys, not paths.
+		add("dotted", map[string]string{"login.url": `https://a.example/"x"`})
+		items, total, err = s.ListItems(context.Background(), user.ID, 0, 0, map[string]string{"login.url": `https://a.example/"x"`}, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 || len(items) != 1 || items[0].Name != "dotted" {
+			t.Fatalf("dotted-key filter got %v (total %d), want just dotted", items, total)
+		}
+
+		// Empty filter means match all.
+		_, total, err = s.ListItems(context.Background(), user.ID, 0, 0, map[string]string{}, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 4 {
+			t.Fatalf("empty filter total = %d, want 4", total)
+		}
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "tagger")
+
+		add := func(name string, tags []string) {
+			t.Helper()
+			if _, err := s.AddItem(context.Background(), &models.Item{
+				UserID: user.ID, Name: name, Type: models.TypeText,
+				Data: []byte("x"), Tags: tags,
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		add("laptop", []string{"work", "hardware"})
+		add("badge", []string{"work"})
+		add("diary", []string{"personal"})
+		add("plain", nil)
+
+		stored, err := s.GetItemByName(context.Background(), user.ID, "laptop")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(stored.Tags, []string{"work", "hardware"}) {
+			t.Fatalf("tags did not survive: %v", stored.Tags)
+		}
+
+		items, total, err := s.ListItems(context.Background(), user.ID, 0, 0, nil, []string{"work"}, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 2 || len(items) != 2 {
+			t.Fatalf("work tag matched %d items (total %d), want 2", len(items), total)
+		}
+
+		items, total, err = s.ListItems(context.Background(), user.ID, 0, 0, nil, []string{"work", "hardware"}, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 || items[0].Name != "laptop" {
+			t.Fatalf("two-tag filter got %v (total %d), want just laptop", items, total)
+		}
+
+		items, total, err = s.ListItems(context.Background(), user.ID, 0, 0, nil, []string{"missing"}, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 0 || len(items) != 0 {
+			t.Fatalf("unknown tag matched %d items", len(items))
+		}
+
+		// Tags can replace each other on update.
+		stored.Tags = []string{"personal"}
+		if err := s.UpdateItem(context.Background(), stored); err != nil {
+			t.Fatal(err)
+		}
+		_, total, err = s.ListItems(context.Background(), user.ID, 0, 0, nil, []string{"personal"}, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 2 {
+			t.Fatalf("after retag personal matched %d items, want 2", total)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "ephemeral")
+
+		past := time.Now().Add(-time.Hour)
+		future := time.Now().Add(time.Hour)
+		expiredID, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "stale-token", Type: models.TypeText,
+			Data: []byte("x"), ExpiresAt: past,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "fresh-token", Type: models.TypeText,
+			Data: []byte("x"), ExpiresAt: future,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		// Give the expired item some history so cleanup has archived
+		// versions to reclaim.
+		if err := s.UpdateItem(context.Background(), &models.Item{
+			UserID: user.ID, ID: expiredID, Data: []byte("y"),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Expired items are invisible to list, search and sync reads.
+		_, total, err := s.ListItems(context.Background(), user.ID, 0, 0, nil, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 {
+			t.Fatalf("list saw %d items, want just the fresh one", total)
+		}
+		found, err := s.SearchItemsByName(context.Background(), user.ID, "token")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(found) != 1 || found[0].Name != "fresh-token" {
+			t.Fatalf("search returned %v, want just fresh-token", found)
+		}
+		changed, err := s.ItemsSince(context.Background(), user.ID, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changed) != 1 {
+			t.Fatalf("sync saw %d items, want 1", len(changed))
+		}
+
+		// Direct get still returns the row so the server can report a
+		// precise "expired" error; cleanup then reclaims it.
+		if _, err := s.GetItem(context.Background(), user.ID, expiredID); err != nil {
+			t.Fatalf("get expired item: %v", err)
+		}
+		removed, err := s.DeleteExpiredItems(context.Background(), time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if removed != 1 {
+			t.Fatalf("cleanup removed %d items, want 1", removed)
+		}
+		if _, err := s.GetItem(context.Background(), user.ID, expiredID); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("after cleanup: got %v, want ErrItemNotFound", err)
+		}
+		// The archived versions must go with it: an expired secret's
+		// prior ciphertexts are not retained.
+		versions, err := s.ListItemVersions(context.Background(), user.ID, expiredID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(versions) != 0 {
+			t.Fatalf("archived versions survived expiry cleanup: %d left", len(versions))
+		}
+	})
+
+	t.Run("SearchByName", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "frank")
+
+		for _, name := range []string{"GitHub-work", "github-home", "mail"} {
+			if _, err := s.AddItem(context.Background(), &models.Item{
+				UserID: user.ID, Name: name, Type: models.TypeText, Data: []byte("x"),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Partial, case-insensitive match.
+		items, err := s.SearchItemsByName(context.Background(), user.ID, "hub")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("search %q matched %d items, want 2", "hub", len(items))
+		}
+
+		items, err = s.SearchItemsByName(context.Background(), user.ID, "GITHUB")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 2 {
+			t.Fatalf("uppercase search matched %d items, want 2", len(items))
+		}
+
+		items, err = s.SearchItemsByName(context.Background(), user.ID, "nothing-like-this")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 0 {
+			t.Fatalf("no-match search returned %d items", len(items))
+		}
+	})
+
+	t.Run("ItemsSince", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "dave")
+
+		if _, err := s.AddItem(context.Background(), &models.Item{
+			UserID: user.ID, Name: "recent", Type: models.TypeText, Data: []byte("x"),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		changed, err := s.ItemsSince(context.Background(), user.ID, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changed) != 1 {
+			t.Fatalf("got %d changed items, want 1", len(changed))
+		}
+
+		unchanged, err := s.ItemsSince(context.Background(), user.ID, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(unchanged) != 0 {
+			t.Fatalf("got %d changed items for a future cutoff, want 0", len(unchanged))
+		}
+	})
+
+	t.Run("ItemsSincePage", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "pager")
+
+		for i := 0; i < 5; i++ {
+			if _, err := s.AddItem(context.Background(), &models.Item{
+				UserID: user.ID, Name: fmt.Sprintf("page-%d", i), Type: models.TypeText, Data: []byte("x"),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Page through with a limit of 2: every item exactly once.
+		seen := make(map[int64]bool)
+		var afterUpdated time.Time
+		var afterID int64
+		pages := 0
+		for {
+			items, more, err := s.ItemsSincePage(context.Background(), user.ID, time.Time{}, afterUpdated, afterID, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pages++
+			for _, item := range items {
+				if seen[item.ID] {
+					t.Fatalf("item %d served twice", item.ID)
+				}
+				seen[item.ID] = true
+			}
+			if !more {
+				break
+			}
+			last := items[len(items)-1]
+			afterUpdated, afterID = last.UpdatedAt, last.ID
+		}
+		if len(seen) != 5 || pages != 3 {
+			t.Fatalf("paged pull served %d items over %d pages, want 5 over 3", len(seen), pages)
+		}
+
+		// Colliding-timestamp boundary: a cursor at an item's exact
+		// updated_at but a lower id must still serve that item, while
+		// a cursor at the item itself must not serve it again.
+		all, _, err := s.ItemsSincePage(context.Background(), user.ID, time.Time{}, time.Time{}, 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mid := all[2]
+		items, _, err := s.ItemsSincePage(context.Background(), user.ID, time.Time{}, mid.UpdatedAt, 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, item := range items {
+			if item.ID == mid.ID {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatal("item sharing the cursor timestamp was skipped")
+		}
+		items, _, err = s.ItemsSincePage(context.Background(), user.ID, time.Time{}, mid.UpdatedAt, mid.ID, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range items {
+			if item.ID == mid.ID {
+				t.Fatal("cursor item served again")
+			}
+		}
+	})
+
+	t.Run("DeleteItems", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "bulk-deleter")
+
+		for _, name := range []string{"bulk-a", "bulk-b", "bulk-keep"} {
+			if _, err := s.AddItem(context.Background(), &models.Item{
+				UserID: user.ID, Name: name, Type: models.TypeText, Data: []byte("x"),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		missing, err := s.DeleteItems(context.Background(), user.ID, []string{"bulk-a", "bulk-b", "bulk-nope"})
+		if err != nil {
+			t.Fatalf("delete items: %v", err)
+		}
+		if len(missing) != 1 || missing[0] != "bulk-nope" {
+			t.Fatalf("missing = %v, want [bulk-nope]", missing)
+		}
+
+		items, total, err := s.ListItems(context.Background(), user.ID, 0, 0, nil, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 1 || items[0].Name != "bulk-keep" {
+			t.Fatalf("remaining items = %v (total %d), want just bulk-keep", items, total)
+		}
+
+		// Both go to the trash as tombstones with bumped versions.
+		trash, err := s.ListTrash(context.Background(), user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trash) != 2 {
+			t.Fatalf("trash holds %d items, want 2", len(trash))
+		}
+		for _, item := range trash {
+			if item.Version != 2 || item.DeletedAt.IsZero() {
+				t.Fatalf("tombstone = %+v, want version 2 and a deletion time", item)
+			}
+		}
+
+		// Deleting an already-deleted item reports it as missing.
+		missing, err = s.DeleteItems(context.Background(), user.ID, []string{"bulk-a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(missing) != 1 {
+			t.Fatalf("re-delete missing = %v, want [bulk-a]", missing)
+		}
+	})
+
+	t.Run("UserIsolation", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		owner := mustCreateUser(t, s, "owner")
+		other := mustCreateUser(t, s, "other")
+
+		id, err := s.AddItem(context.Background(), &models.Item{
+			UserID: owner.ID, Name: "private", Type: models.TypeText, Data: []byte("x"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetItem(context.Background(), other.ID, id); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("cross-user get: got %v, want ErrItemNotFound", err)
+		}
+		items, total, err := s.ListItems(context.Background(), other.ID, 0, 0, nil, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 0 || total != 0 {
+			t.Fatal("cross-user list leaked items")
+		}
+		if err := s.DeleteItem(context.Background(), other.ID, id); !errors.Is(err, ErrItemNotFound) {
+			t.Fatalf("cross-user delete: got %v, want ErrItemNotFound", err)
+		}
+		if _, err := s.GetItem(context.Background(), owner.ID, id); err != nil {
+			t.Fatalf("item damaged by cross-user delete attempt: %v", err)
+		}
+	})
+
+	t.Run("AuditLog", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "auditor")
+		other := mustCreateUser(t, s, "bystander-auditor")
+
+		for i, action := range []string{"add", "get", "delete"} {
+			entry := &models.AuditEntry{UserID: user.ID, Action: action, ItemID: int64(i + 1), IP: "127.0.0.1"}
+			if err := s.AppendAuditEntry(context.Background(), entry); err != nil {
+				t.Fatalf("append %q: %v", action, err)
+			}
+			if entry.ID == 0 || entry.CreatedAt.IsZero() {
+				t.Fatalf("entry %q not filled in: %+v", action, entry)
+			}
+		}
+
+		entries, err := s.ListAuditEntries(context.Background(), user.ID, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 3 {
+			t.Fatalf("got %d entries, want 3", len(entries))
+		}
+		if entries[0].Action != "delete" || entries[2].Action != "add" {
+			t.Fatalf("entries not newest first: %q .. %q", entries[0].Action, entries[2].Action)
+		}
+
+		limited, err := s.ListAuditEntries(context.Background(), user.ID, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(limited) != 2 || limited[0].Action != "delete" {
+			t.Fatalf("limit 2 returned %d entries starting %q", len(limited), limited[0].Action)
+		}
+
+		foreign, err := s.ListAuditEntries(context.Background(), other.ID, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(foreign) != 0 {
+			t.Fatalf("other user sees %d foreign entries, want 0", len(foreign))
+		}
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		s := open(t)
+		defer s.Close()
+		user := mustCreateUser(t, s, "cancelled")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, _, err := s.ListItems(ctx, user.ID, 0, 0, nil, nil, "", ""); !errors.Is(err, context.Canceled) {
+			t.Fatalf("list with cancelled context: got %v, want context.Canceled", err)
+		}
+		if _, err := s.AddItem(ctx, &models.Item{
+			UserID: user.ID, Name: "never", Type: models.TypeText, Data: []byte("x"),
+		}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("add with cancelled context: got %v, want context.Canceled", err)
+		}
+	})
+}
+
+func mustCreateUser(t *testing.T, s Storage, login string) *models.User {
+	t.Helper()
+	user, err := s.CreateUser(context.Background(), login, "hash")
+	if err != nil {
+		t.Fatalf("create user %q: %v", login, err)
+	}
+	return user
+}
+
+func TestSQLiteStorage(t *testing.T) {
+	runStorageTests(t, func(t *testing.T) Storage {
+		s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "gophkeeper.db"))
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		return s
+	})
+}
+
+// TestPostgresStorageSuite needs a real database and only runs when
+// TEST_DATABASE_DSN is set. Each run wipes the tables first.
+func TestPostgresStorageSuite(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+	runStorageTests(t, func(t *testing.T) Storage {
+		s, err := NewPostgresStorage(dsn)
+		if err != nil {
+			t.Fatalf("open postgres: %v", err)
+		}
+		if _, err := s.db.Exec(`TRUNCATE refresh_tokens, items, users RESTART IDENTITY CASCADE`); err != nil {
+			t.Fatalf("reset tables: %v", err)
+		}
+		return s
+	})
+}
@@ -0,0 +1,880 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// MemoryStorage is an in-memory Storage implementation for tests and
+// demos. It enforces the same constraints as the database backends:
+// unique logins, a unique (user_id, name) pair per item, and version
+// conflicts on stale updates.
+type MemoryStorage struct {
+	mu            sync.RWMutex
+	users         map[int64]*models.User
+	items         map[int64]*models.Item
+	versions      map[int64][]*models.ItemVersion
+	refreshTokens map[string]*models.RefreshToken
+	revokedTokens map[string]time.Time
+	audit         map[int64][]*models.AuditEntry
+	nextUserID    int64
+	nextItemID    int64
+	nextAuditID   int64
+}
+
+// NewMemoryStorage returns an empty in-memory store.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		users:         map[int64]*models.User{},
+		items:         map[int64]*models.Item{},
+		versions:      map[int64][]*models.ItemVersion{},
+		refreshTokens: map[string]*models.RefreshToken{},
+		revokedTokens: map[string]time.Time{},
+		audit:         map[int64][]*models.AuditEntry{},
+	}
+}
+
+// CreateUser inserts a new user and returns it with the assigned ID.
+func (s *MemoryStorage) CreateUser(ctx context.Context, login, passwordHash string) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range s.users {
+		if u.Login == login {
+			return nil, ErrUserExists
+		}
+	}
+	s.nextUserID++
+	user := &models.User{
+		ID: s.nextUserID, Login: login, PasswordHash: passwordHash, CreatedAt: time.Now(),
+	}
+	s.users[user.ID] = user
+	copied := *user
+	return &copied, nil
+}
+
+// GetUserByLogin fetches a user by login name.
+func (s *MemoryStorage) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, u := range s.users {
+		if u.Login == login {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+// GetUserByID fetches a user by ID.
+func (s *MemoryStorage) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u, ok := s.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	copied := *u
+	return &copied, nil
+}
+
+// UpdateUserPassword replaces the user's stored password hash.
+func (s *MemoryStorage) UpdateUserPassword(ctx context.Context, userID int64, passwordHash string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	user.PasswordHash = passwordHash
+	return nil
+}
+
+// UpdateUserLastLogin stamps the user's most recent successful login
+// with the current time and the caller's address.
+func (s *MemoryStorage) UpdateUserLastLogin(ctx context.Context, userID int64, ip string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	user.LastLoginAt = time.Now()
+	user.LastLoginIP = ip
+	return nil
+}
+
+// DeleteUser permanently removes the user and everything they own:
+// items, archived versions and refresh tokens.
+func (s *MemoryStorage) DeleteUser(ctx context.Context, userID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[userID]; !ok {
+		return ErrUserNotFound
+	}
+	delete(s.users, userID)
+	for id, item := range s.items {
+		if item.UserID == userID {
+			delete(s.items, id)
+			delete(s.versions, id)
+		}
+	}
+	for token, rt := range s.refreshTokens {
+		if rt.UserID == userID {
+			delete(s.refreshTokens, token)
+		}
+	}
+	delete(s.audit, userID)
+	return nil
+}
+
+// CreateRefreshToken records an issued refresh token.
+func (s *MemoryStorage) CreateRefreshToken(ctx context.Context, userID int64, token string, expiresAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshTokens[token] = &models.RefreshToken{Token: token, UserID: userID, ExpiresAt: expiresAt}
+	return nil
+}
+
+// GetRefreshToken fetches a refresh token record.
+func (s *MemoryStorage) GetRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rt, ok := s.refreshTokens[token]
+	if !ok {
+		return nil, ErrRefreshTokenNotFound
+	}
+	copied := *rt
+	return &copied, nil
+}
+
+// DeleteRefreshToken revokes a refresh token.
+func (s *MemoryStorage) DeleteRefreshToken(ctx context.Context, token string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.refreshTokens, token)
+	return nil
+}
+
+// DeleteUserRefreshTokens revokes every refresh token the user holds.
+func (s *MemoryStorage) DeleteUserRefreshTokens(ctx context.Context, userID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token, rt := range s.refreshTokens {
+		if rt.UserID == userID {
+			delete(s.refreshTokens, token)
+		}
+	}
+	return nil
+}
+
+// RevokeToken blacklists an access token by its jti.
+func (s *MemoryStorage) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.revokedTokens[jti]; !ok {
+		s.revokedTokens[jti] = expiresAt
+	}
+	return nil
+}
+
+// IsTokenRevoked reports whether a jti is on the revocation list.
+func (s *MemoryStorage) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, revoked := s.revokedTokens[jti]
+	return revoked, nil
+}
+
+// AddItem inserts an item and returns its assigned ID.
+func (s *MemoryStorage) AddItem(ctx context.Context, item *models.Item) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addItemLocked(item)
+}
+
+// AddItems inserts all items atomically: names are validated up front
+// so either every item is stored or none are.
+func (s *MemoryStorage) AddItems(ctx context.Context, items []*models.Item) ([]int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[int64]map[string]bool)
+	for _, item := range items {
+		names := seen[item.UserID]
+		if names == nil {
+			names = make(map[string]bool)
+			seen[item.UserID] = names
+		}
+		if names[item.Name] {
+			return nil, fmt.Errorf("item %q: %w", item.Name, ErrItemExists)
+		}
+		names[item.Name] = true
+		for _, existing := range s.items {
+			if existing.UserID == item.UserID && existing.Name == item.Name && existing.DeletedAt.IsZero() {
+				return nil, fmt.Errorf("item %q: %w", item.Name, ErrItemExists)
+			}
+		}
+	}
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		id, err := s.addItemLocked(item)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *MemoryStorage) addItemLocked(item *models.Item) (int64, error) {
+	for id, existing := range s.items {
+		if existing.UserID != item.UserID || existing.Name != item.Name {
+			continue
+		}
+		if existing.DeletedAt.IsZero() {
+			return 0, ErrItemExists
+		}
+		// A soft-deleted item does not block reusing the name; purge
+		// it so the new item takes its place.
+		delete(s.items, id)
+		delete(s.versions, id)
+	}
+	s.nextItemID++
+	now := time.Now()
+	stored := copyItem(item)
+	stored.ID = s.nextItemID
+	stored.Version = 1
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	s.items[stored.ID] = stored
+	return stored.ID, nil
+}
+
+// GetItem fetches a single item owned by userID.
+func (s *MemoryStorage) GetItem(ctx context.Context, userID, itemID int64) (*models.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	item, ok := s.items[itemID]
+	if !ok || item.UserID != userID || !item.DeletedAt.IsZero() {
+		return nil, ErrItemNotFound
+	}
+	return copyItem(item), nil
+}
+
+// GetItemByName fetches a single item owned by userID by its name.
+func (s *MemoryStorage) GetItemByName(ctx context.Context, userID int64, name string) (*models.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, item := range s.items {
+		if item.UserID == userID && item.Name == name && item.DeletedAt.IsZero() {
+			return copyItem(item), nil
+		}
+	}
+	return nil, ErrItemNotFound
+}
+
+// ListItems returns one page of items owned by userID along with the
+// total matching item count. The default order is stable
+// (created_at, id); sortBy and order pick another one.
+func (s *MemoryStorage) ListItems(ctx context.Context, userID, limit, offset int64, filter map[string]string, tags []string, sortBy, order string) ([]*models.Item, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	if err := ValidateSort(sortBy, order); err != nil {
+		return nil, 0, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := s.userItems(userID)
+	sortItems(all, sortBy, order)
+	if len(filter) > 0 || len(tags) > 0 {
+		var filtered []*models.Item
+		for _, item := range all {
+			if metadataContains(item.Metadata, filter) && tagsContain(item.Tags, tags) {
+				filtered = append(filtered, item)
+			}
+		}
+		all = filtered
+	}
+	total := int64(len(all))
+
+	if offset > total {
+		offset = total
+	}
+	all = all[offset:]
+	if limit > 0 && int64(len(all)) > limit {
+		all = all[:limit]
+	}
+	return all, total, nil
+}
+
+// CountItemsByType returns per-type statistics for the user's live
+// items.
+func (s *MemoryStorage) CountItemsByType(ctx context.Context, userID int64) (map[string]TypeStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]TypeStats)
+	for _, item := range s.userItems(userID) {
+		st := out[item.Type]
+		st.Count++
+		st.TotalSize += int64(len(item.Data))
+		if st.Oldest.IsZero() || item.CreatedAt.Before(st.Oldest) {
+			st.Oldest = item.CreatedAt
+		}
+		if item.CreatedAt.After(st.Newest) {
+			st.Newest = item.CreatedAt
+		}
+		out[item.Type] = st
+	}
+	return out, nil
+}
+
+// DeleteExpiredItems removes items whose expiry has passed, along with
+// their archived versions: a self-destructing secret must not live on
+// in the history table.
+func (s *MemoryStorage) DeleteExpiredItems(ctx context.Context, now time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var removed int64
+	for id, item := range s.items {
+		if !item.ExpiresAt.IsZero() && !item.ExpiresAt.After(now) {
+			delete(s.items, id)
+			delete(s.versions, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// ItemsSince returns items owned by userID changed after since.
+// Soft-deleted items are included as tombstones so clients can drop
+// them from local caches.
+func (s *MemoryStorage) ItemsSince(ctx context.Context, userID int64, since time.Time) ([]*models.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now()
+	var out []*models.Item
+	for _, item := range s.items {
+		if item.UserID != userID || !item.UpdatedAt.After(since) {
+			continue
+		}
+		if item.DeletedAt.IsZero() && !item.ExpiresAt.IsZero() && !item.ExpiresAt.After(now) {
+			continue
+		}
+		out = append(out, copyItem(item))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// ItemsSincePage returns one keyset page of items changed after since,
+// ordered by (updated_at, id) and starting strictly after the
+// (afterUpdated, afterID) cursor.
+func (s *MemoryStorage) ItemsSincePage(ctx context.Context, userID int64, since, afterUpdated time.Time, afterID, limit int64) ([]*models.Item, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now()
+	var out []*models.Item
+	for _, item := range s.items {
+		if item.UserID != userID || !item.UpdatedAt.After(since) {
+			continue
+		}
+		if item.UpdatedAt.Before(afterUpdated) ||
+			(item.UpdatedAt.Equal(afterUpdated) && item.ID <= afterID) {
+			continue
+		}
+		if item.DeletedAt.IsZero() && !item.ExpiresAt.IsZero() && !item.ExpiresAt.After(now) {
+			continue
+		}
+		out = append(out, copyItem(item))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].UpdatedAt.Equal(out[j].UpdatedAt) {
+			return out[i].UpdatedAt.Before(out[j].UpdatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	more := limit > 0 && int64(len(out)) > limit
+	if more {
+		out = out[:limit]
+	}
+	return out, more, nil
+}
+
+// SearchItemsByName returns items owned by userID whose name contains
+// query, case-insensitively.
+func (s *MemoryStorage) SearchItemsByName(ctx context.Context, userID int64, query string) ([]*models.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	query = strings.ToLower(query)
+	var out []*models.Item
+	for _, item := range s.userItems(userID) {
+		if strings.Contains(strings.ToLower(item.Name), query) {
+			out = append(out, item)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// UpdateItem overwrites an item's payload and metadata, bumping its
+// version. A non-zero item.Version that does not match the stored one
+// is rejected as a conflict.
+func (s *MemoryStorage) UpdateItem(ctx context.Context, item *models.Item) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.items[item.ID]
+	if !ok || stored.UserID != item.UserID || !stored.DeletedAt.IsZero() {
+		return ErrItemNotFound
+	}
+	if item.Version != 0 && item.Version != stored.Version {
+		return ErrVersionConflict
+	}
+	s.archiveItemVersion(stored)
+	stored.Data = append([]byte(nil), item.Data...)
+	stored.Metadata = copyMetadata(item.Metadata)
+	stored.Tags = append([]string(nil), item.Tags...)
+	stored.Version++
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateItemByName updates the user's item with the given name, with
+// the same version check as UpdateItem. The single lock makes the
+// lookup and update atomic.
+func (s *MemoryStorage) UpdateItemByName(ctx context.Context, item *models.Item) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var stored *models.Item
+	for _, candidate := range s.items {
+		if candidate.UserID == item.UserID && candidate.Name == item.Name && candidate.DeletedAt.IsZero() {
+			stored = candidate
+			break
+		}
+	}
+	if stored == nil {
+		return ErrItemNotFound
+	}
+	if item.Version != 0 && item.Version != stored.Version {
+		return ErrVersionConflict
+	}
+	s.archiveItemVersion(stored)
+	stored.Data = append([]byte(nil), item.Data...)
+	stored.Metadata = copyMetadata(item.Metadata)
+	stored.Tags = append([]string(nil), item.Tags...)
+	stored.Version++
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+// archiveItemVersion records the item's current value before an update
+// overwrites it. Callers must hold the lock.
+func (s *MemoryStorage) archiveItemVersion(stored *models.Item) {
+	s.versions[stored.ID] = append(s.versions[stored.ID], &models.ItemVersion{
+		ID:         int64(len(s.versions[stored.ID]) + 1),
+		ItemID:     stored.ID,
+		UserID:     stored.UserID,
+		Version:    stored.Version,
+		Data:       append([]byte(nil), stored.Data...),
+		Metadata:   copyMetadata(stored.Metadata),
+		Tags:       append([]string(nil), stored.Tags...),
+		ArchivedAt: time.Now(),
+	})
+}
+
+// ListItemVersions returns archived prior values of an item, newest
+// first.
+func (s *MemoryStorage) ListItemVersions(ctx context.Context, userID, itemID int64) ([]*models.ItemVersion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	archived := s.versions[itemID]
+	var versions []*models.ItemVersion
+	for i := len(archived) - 1; i >= 0; i-- {
+		if archived[i].UserID != userID {
+			continue
+		}
+		copied := *archived[i]
+		versions = append(versions, &copied)
+	}
+	return versions, nil
+}
+
+// DeleteItem soft-deletes an item owned by userID. The item stays in
+// place as a tombstone (with its version bumped so sync picks it up)
+// until PurgeDeleted or PurgeItem removes it for good.
+func (s *MemoryStorage) DeleteItem(ctx context.Context, userID, itemID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[itemID]
+	if !ok || item.UserID != userID || !item.DeletedAt.IsZero() {
+		return ErrItemNotFound
+	}
+	item.DeletedAt = time.Now()
+	item.Version++
+	item.UpdatedAt = item.DeletedAt
+	return nil
+}
+
+// DeleteItems soft-deletes the named items in one pass and returns the
+// names that had no live item.
+func (s *MemoryStorage) DeleteItems(ctx context.Context, userID int64, names []string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	byName := make(map[string]*models.Item, len(s.items))
+	for _, item := range s.items {
+		if item.UserID == userID && item.DeletedAt.IsZero() {
+			byName[item.Name] = item
+		}
+	}
+	var missing []string
+	now := time.Now()
+	for _, name := range names {
+		item, ok := byName[name]
+		if !ok || !item.DeletedAt.IsZero() {
+			missing = append(missing, name)
+			continue
+		}
+		item.DeletedAt = now
+		item.Version++
+		item.UpdatedAt = now
+	}
+	return missing, nil
+}
+
+// ListTrash returns the user's soft-deleted items, most recently
+// deleted first.
+func (s *MemoryStorage) ListTrash(ctx context.Context, userID int64) ([]*models.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []*models.Item
+	for _, item := range s.items {
+		if item.UserID == userID && !item.DeletedAt.IsZero() {
+			out = append(out, copyItem(item))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].DeletedAt.Equal(out[j].DeletedAt) {
+			return out[i].DeletedAt.After(out[j].DeletedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	return out, nil
+}
+
+// RestoreItem clears a soft-deleted item's tombstone and bumps its
+// version.
+func (s *MemoryStorage) RestoreItem(ctx context.Context, userID, itemID int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[itemID]
+	if !ok || item.UserID != userID || item.DeletedAt.IsZero() {
+		return ErrItemNotFound
+	}
+	item.DeletedAt = time.Time{}
+	item.Version++
+	item.UpdatedAt = time.Now()
+	return nil
+}
+
+// PurgeDeleted hard-deletes items soft-deleted at or before olderThan,
+// along with their archived versions. A userID of 0 purges across all
+// users (used by the background reaper).
+func (s *MemoryStorage) PurgeDeleted(ctx context.Context, userID int64, olderThan time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var removed int64
+	for id, item := range s.items {
+		if userID != 0 && item.UserID != userID {
+			continue
+		}
+		if item.DeletedAt.IsZero() || item.DeletedAt.After(olderThan) {
+			continue
+		}
+		delete(s.items, id)
+		delete(s.versions, id)
+		removed++
+	}
+	return removed, nil
+}
+
+// PurgeItem hard-deletes one item by ID, or by name when itemID is
+// zero, whether or not it was soft-deleted first.
+func (s *MemoryStorage) PurgeItem(ctx context.Context, userID, itemID int64, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, item := range s.items {
+		if item.UserID != userID {
+			continue
+		}
+		if itemID != 0 && item.ID != itemID {
+			continue
+		}
+		if itemID == 0 && item.Name != name {
+			continue
+		}
+		delete(s.items, id)
+		delete(s.versions, id)
+		return nil
+	}
+	return ErrItemNotFound
+}
+
+// AppendAuditEntry records one data-access event.
+func (s *MemoryStorage) AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextAuditID++
+	entry.ID = s.nextAuditID
+	entry.CreatedAt = time.Now()
+	stored := *entry
+	s.audit[entry.UserID] = append(s.audit[entry.UserID], &stored)
+	return nil
+}
+
+// ListAuditEntries returns the user's audit entries, newest first.
+func (s *MemoryStorage) ListAuditEntries(ctx context.Context, userID, limit int64) ([]*models.AuditEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored := s.audit[userID]
+	out := make([]*models.AuditEntry, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		if limit > 0 && int64(len(out)) >= limit {
+			break
+		}
+		entry := *stored[i]
+		out = append(out, &entry)
+	}
+	return out, nil
+}
+
+// Ping always succeeds for the in-memory store.
+func (s *MemoryStorage) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close is a no-op for the in-memory store.
+func (s *MemoryStorage) Close() error {
+	return nil
+}
+
+// userItems returns copies of the user's live (non-expired,
+// non-deleted) items sorted by (created_at, id). Callers must hold at
+// least the read lock.
+func (s *MemoryStorage) userItems(userID int64) []*models.Item {
+	now := time.Now()
+	var out []*models.Item
+	for _, item := range s.items {
+		if item.UserID != userID || !item.DeletedAt.IsZero() {
+			continue
+		}
+		if !item.ExpiresAt.IsZero() && !item.ExpiresAt.After(now) {
+			continue
+		}
+		out = append(out, copyItem(item))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func copyItem(item *models.Item) *models.Item {
+	copied := *item
+	copied.Data = append([]byte(nil), item.Data...)
+	copied.Metadata = copyMetadata(item.Metadata)
+	copied.Tags = append([]string(nil), item.Tags...)
+	return &copied
+}
+
+// metadataContains reports whether meta includes every key/value pair
+// from filter.
+func metadataContains(meta, filter map[string]string) bool {
+	for k, v := range filter {
+		if meta[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// tagsContain reports whether have includes every tag in want.
+func tagsContain(have, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func copyMetadata(meta map[string]string) map[string]string {
+	if meta == nil {
+		return map[string]string{}
+	}
+	out := make(map[string]string, len(meta))
+	for k, v := range meta {
+		out[k] = v
+	}
+	return out
+}
+
+// sortItems orders items by the given sort parameters, mirroring the
+// SQL backends' ORDER BY column with the id tiebreaker.
+func sortItems(items []*models.Item, sortBy, order string) {
+	var less func(a, b *models.Item) bool
+	switch sortBy {
+	case SortByName:
+		less = func(a, b *models.Item) bool {
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.ID < b.ID
+		}
+	case SortByType:
+		less = func(a, b *models.Item) bool {
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			return a.ID < b.ID
+		}
+	case SortByUpdated:
+		less = func(a, b *models.Item) bool {
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.Before(b.UpdatedAt)
+			}
+			return a.ID < b.ID
+		}
+	default: // created, the default order userItems already returns
+		less = func(a, b *models.Item) bool {
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+			return a.ID < b.ID
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if order == OrderDesc {
+			i, j = j, i
+		}
+		return less(items[i], items[j])
+	})
+}
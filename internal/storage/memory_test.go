@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	runStorageTests(t, func(t *testing.T) Storage {
+		return NewMemoryStorage()
+	})
+}
+
+func TestMemoryUpdateItemVersionConflict(t *testing.T) {
+	s := NewMemoryStorage()
+	user := mustCreateUser(t, s, "alice")
+	id, err := s.AddItem(context.Background(), &models.Item{UserID: user.ID, Name: "mail", Type: models.TypeText, Data: []byte("x")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := s.GetItem(context.Background(), user.ID, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateItem(context.Background(), item); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+
+	// A second update based on the stale version must be rejected.
+	if err := s.UpdateItem(context.Background(), item); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("stale update: got %v, want ErrVersionConflict", err)
+	}
+}
+
+func TestMemoryAddItemDuplicateName(t *testing.T) {
+	s := NewMemoryStorage()
+	user := mustCreateUser(t, s, "alice")
+	if _, err := s.AddItem(context.Background(), &models.Item{UserID: user.ID, Name: "mail", Type: models.TypeText, Data: []byte("x")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddItem(context.Background(), &models.Item{UserID: user.ID, Name: "mail", Type: models.TypeText, Data: []byte("y")}); !errors.Is(err, ErrItemExists) {
+		t.Fatalf("duplicate name: got %v, want ErrItemExists", err)
+	}
+}
+
+func TestMemoryItemsSinceOrdering(t *testing.T) {
+	s := NewMemoryStorage()
+	user := mustCreateUser(t, s, "alice")
+	for i := 0; i < 3; i++ {
+		if _, err := s.AddItem(context.Background(), &models.Item{UserID: user.ID, Name: fmt.Sprintf("item-%d", i), Type: models.TypeText, Data: []byte("x")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, err := s.ItemsSince(context.Background(), user.ID, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+	for i := 1; i < len(items); i++ {
+		prev, cur := items[i-1], items[i]
+		if cur.CreatedAt.Before(prev.CreatedAt) ||
+			(cur.CreatedAt.Equal(prev.CreatedAt) && cur.ID < prev.ID) {
+			t.Fatalf("items out of (created_at, id) order: %d before %d", prev.ID, cur.ID)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFS embed.FS
+
+// migration is one numbered schema change.
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// loadMigrations reads the embedded migrations for a dialect, sorted by
+// version. File names follow NNNN_description.sql.
+func loadMigrations(dialect string) ([]migration, error) {
+	dir := "migrations/" + dialect
+	entries, err := migrationFS.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+	var out []migration
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("malformed migration file name %q", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("malformed migration version in %q: %w", name, err)
+		}
+		body, err := migrationFS.ReadFile(dir + "/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %q: %w", name, err)
+		}
+		out = append(out, migration{version: version, name: name, sql: string(body)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].version < out[j].version })
+	return out, nil
+}
+
+// runMigrations applies all pending migrations for the dialect exactly
+// once, tracking progress in the schema_migrations table. It refuses to
+// run against a database whose schema version is newer than the binary
+// knows about.
+func runMigrations(ctx context.Context, db *sql.DB, dialect string) error {
+	migrations, err := loadMigrations(dialect)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name    TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var current sql.NullInt64
+	if err := db.QueryRowContext(ctx, `SELECT max(version) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].version
+	}
+	if current.Valid && int(current.Int64) > latest {
+		return fmt.Errorf("database schema version %d is newer than this binary supports (%d); upgrade the server",
+			current.Int64, latest)
+	}
+
+	for _, m := range migrations {
+		if current.Valid && m.version <= int(current.Int64) {
+			continue
+		}
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("begin migration %s: %w", m.name, err)
+		}
+		if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("apply migration %s: %w", m.name, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			placeholderInsert(dialect), m.version, m.name); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("record migration %s: %w", m.name, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("commit migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+func placeholderInsert(dialect string) string {
+	if dialect == "postgres" {
+		return `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`
+	}
+	return `INSERT INTO schema_migrations (version, name) VALUES (?, ?)`
+}
+
+// MigrateUp applies pending migrations.
+func (s *PostgresStorage) MigrateUp(ctx context.Context) error {
+	return runMigrations(ctx, s.db, "postgres")
+}
+
+// MigrateUp applies pending migrations.
+func (s *SQLiteStorage) MigrateUp(ctx context.Context) error {
+	return runMigrations(ctx, s.db, "sqlite")
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrateUpAppliesAndRecordsVersions(t *testing.T) {
+	s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "gophkeeper.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer s.Close()
+
+	var version int
+	if err := s.db.QueryRow(`SELECT max(version) FROM schema_migrations`).Scan(&version); err != nil {
+		t.Fatalf("read schema version: %v", err)
+	}
+	if version < 1 {
+		t.Fatalf("schema version = %d, want at least 1", version)
+	}
+
+	// Rerunning must be a no-op, not a duplicate-apply error.
+	if err := s.MigrateUp(context.Background()); err != nil {
+		t.Fatalf("second MigrateUp: %v", err)
+	}
+	var count int
+	if err := s.db.QueryRow(`SELECT count(*) FROM schema_migrations`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != version {
+		t.Fatalf("schema_migrations has %d rows, want %d", count, version)
+	}
+}
+
+func TestMigrateUpRefusesNewerSchema(t *testing.T) {
+	s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "gophkeeper.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if _, err := s.db.Exec(`INSERT INTO schema_migrations (version, name) VALUES (9999, 'from_the_future')`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.MigrateUp(context.Background()); err == nil {
+		t.Fatal("expected MigrateUp to refuse a newer schema")
+	}
+}
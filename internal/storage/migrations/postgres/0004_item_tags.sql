@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]';
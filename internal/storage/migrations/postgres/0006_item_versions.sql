@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS item_versions (
+    id          BIGSERIAL PRIMARY KEY,
+    item_id     BIGINT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id     BIGINT NOT NULL,
+    version     BIGINT NOT NULL,
+    data        BYTEA NOT NULL,
+    metadata    JSONB NOT NULL DEFAULT '{}',
+    tags        JSONB NOT NULL DEFAULT '[]',
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (item_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS item_versions_item_id_idx ON item_versions (item_id);
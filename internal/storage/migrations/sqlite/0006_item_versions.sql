@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS item_versions (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id     INTEGER NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    user_id     INTEGER NOT NULL,
+    version     INTEGER NOT NULL,
+    data        BLOB NOT NULL,
+    metadata    TEXT NOT NULL DEFAULT '{}',
+    tags        TEXT NOT NULL DEFAULT '[]',
+    archived_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS item_versions_item_id_idx ON item_versions (item_id);
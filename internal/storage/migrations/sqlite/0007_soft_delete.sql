@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN deleted_at DATETIME;
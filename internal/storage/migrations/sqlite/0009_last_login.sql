@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN last_login_at DATETIME;
+ALTER TABLE users ADD COLUMN last_login_ip TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestSetPoolAppliesConfig(t *testing.T) {
+	// sql.Open does not connect, so no Postgres server is needed to
+	// observe the pool settings.
+	db, err := sql.Open("postgres", "postgres://pool-test/unused")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	s := &PostgresStorage{db: db}
+
+	s.SetPool(PoolConfig{MaxOpenConns: 7, MaxIdleConns: 3, ConnMaxLifetime: time.Minute})
+	if got := s.DBStats().MaxOpenConnections; got != 7 {
+		t.Fatalf("MaxOpenConnections = %d, want 7", got)
+	}
+
+	// Zero fields fall back to the package defaults.
+	s.SetPool(PoolConfig{})
+	if got := s.DBStats().MaxOpenConnections; got != DefaultMaxOpenConns {
+		t.Fatalf("MaxOpenConnections = %d, want the default %d", got, DefaultMaxOpenConns)
+	}
+
+	// Negative means unlimited, reported as zero by the pool.
+	s.SetPool(PoolConfig{MaxOpenConns: -1})
+	if got := s.DBStats().MaxOpenConnections; got != 0 {
+		t.Fatalf("MaxOpenConnections = %d, want 0 (unlimited)", got)
+	}
+}
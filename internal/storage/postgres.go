@@ -0,0 +1,1018 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// PostgresStorage implements Storage on top of PostgreSQL.
+type PostgresStorage struct {
+	db *sql.DB
+}
+
+// defaultConnectTimeout bounds how long the initial connection is
+// retried before NewPostgresStorage gives up.
+const defaultConnectTimeout = 30 * time.Second
+
+// connectRetryBase is the delay before the first reconnection attempt;
+// it doubles after every failure.
+const connectRetryBase = time.Second
+
+// NewPostgresStorage connects to the database at dsn and initializes
+// the schema. The initial connection is retried for up to
+// defaultConnectTimeout, since under docker-compose the server often
+// starts before Postgres is ready to accept connections.
+func NewPostgresStorage(dsn string) (*PostgresStorage, error) {
+	return NewPostgresStorageWithTimeout(dsn, defaultConnectTimeout)
+}
+
+// NewPostgresStorageWithTimeout is NewPostgresStorage with an explicit
+// bound on how long to keep retrying the initial connection.
+func NewPostgresStorageWithTimeout(dsn string, connectTimeout time.Duration) (*PostgresStorage, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if err := pingWithRetry(db.Ping, connectTimeout, connectRetryBase); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	applyPool(db, PoolConfig{})
+	s := &PostgresStorage{db: db}
+	if err := s.MigrateUp(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetPool applies cfg to the connection pool. Zero fields fall back to
+// the package defaults.
+func (s *PostgresStorage) SetPool(cfg PoolConfig) {
+	applyPool(s.db, cfg)
+}
+
+// applyPool configures db's connection pool, substituting the package
+// defaults for zero fields.
+func applyPool(db *sql.DB, cfg PoolConfig) {
+	if cfg.MaxOpenConns == 0 {
+		cfg.MaxOpenConns = DefaultMaxOpenConns
+	}
+	if cfg.MaxIdleConns == 0 {
+		cfg.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if cfg.ConnMaxLifetime == 0 {
+		cfg.ConnMaxLifetime = DefaultConnMaxLifetime
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+}
+
+// pingWithRetry calls ping until it succeeds or timeout elapses,
+// doubling the delay between attempts starting from base and logging
+// each failed attempt. It never sleeps past the deadline: when the next
+// delay would cross it, the last error is returned instead.
+func pingWithRetry(ping func() error, timeout, base time.Duration) error {
+	if timeout <= 0 {
+		timeout = defaultConnectTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	delay := base
+	for attempt := 1; ; attempt++ {
+		err := ping()
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("database unreachable after %d attempts: %w", attempt, err)
+		}
+		slog.Warn("database not ready, retrying", "attempt", attempt, "delay", delay, "error", err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// withTx runs fn inside a transaction, committing when it returns nil
+// and rolling back otherwise. Every operation touching more than one
+// table (or more than one row dependently) goes through it, so a crash
+// mid-operation cannot leave half the change behind.
+func (s *PostgresStorage) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreateUser inserts a new user and returns it with the assigned ID.
+func (s *PostgresStorage) CreateUser(ctx context.Context, login, passwordHash string) (*models.User, error) {
+	user := &models.User{Login: login, PasswordHash: passwordHash}
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO users (login, password_hash) VALUES ($1, $2) RETURNING id, created_at`,
+		login, passwordHash,
+	).Scan(&user.ID, &user.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrUserExists
+		}
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	return user, nil
+}
+
+// isUniqueViolation reports whether err is Postgres signalling a unique
+// constraint violation (SQLSTATE 23505), which is how duplicate logins
+// and duplicate (user_id, name) item pairs surface.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// GetUserByLogin fetches a user by login name.
+func (s *PostgresStorage) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	return s.scanUser(s.db.QueryRowContext(ctx,
+		`SELECT id, login, password_hash, created_at, last_login_at, last_login_ip FROM users WHERE login = $1`,
+		login,
+	))
+}
+
+// GetUserByID fetches a user by ID.
+func (s *PostgresStorage) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
+	return s.scanUser(s.db.QueryRowContext(ctx,
+		`SELECT id, login, password_hash, created_at, last_login_at, last_login_ip FROM users WHERE id = $1`,
+		id,
+	))
+}
+
+// scanUser reads one user row, mapping the nullable last-login column
+// onto the model's zero value.
+func (s *PostgresStorage) scanUser(row *sql.Row) (*models.User, error) {
+	user := &models.User{}
+	var lastLogin sql.NullTime
+	err := row.Scan(&user.ID, &user.Login, &user.PasswordHash, &user.CreatedAt, &lastLogin, &user.LastLoginIP)
+	if err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if lastLogin.Valid {
+		user.LastLoginAt = lastLogin.Time
+	}
+	return user, nil
+}
+
+// UpdateUserLastLogin stamps the user's most recent successful login
+// with the current time and the caller's address.
+func (s *PostgresStorage) UpdateUserLastLogin(ctx context.Context, userID int64, ip string) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET last_login_at = now(), last_login_ip = $1 WHERE id = $2`,
+		ip, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("update user last login: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("update user last login: %w", err)
+	} else if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateUserPassword replaces the user's stored password hash.
+func (s *PostgresStorage) UpdateUserPassword(ctx context.Context, userID int64, passwordHash string) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET password_hash = $1 WHERE id = $2`,
+		passwordHash, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("update user password: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("update user password: %w", err)
+	} else if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// DeleteUser permanently removes the user and everything they own:
+// items, archived versions, refresh tokens and audit entries.
+func (s *PostgresStorage) DeleteUser(ctx context.Context, userID int64) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM item_versions WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM items WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM audit_log WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+		if err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		removed, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if removed == 0 {
+			return ErrUserNotFound
+		}
+		return nil
+	})
+}
+
+// CreateRefreshToken records an issued refresh token.
+func (s *PostgresStorage) CreateRefreshToken(ctx context.Context, userID int64, token string, expiresAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO refresh_tokens (token, user_id, expires_at) VALUES ($1, $2, $3)`,
+		token, userID, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshToken fetches a refresh token record.
+func (s *PostgresStorage) GetRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	rt := &models.RefreshToken{}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT token, user_id, expires_at FROM refresh_tokens WHERE token = $1`,
+		token,
+	).Scan(&rt.Token, &rt.UserID, &rt.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get refresh token: %w", err)
+	}
+	return rt, nil
+}
+
+// DeleteRefreshToken revokes a refresh token.
+func (s *PostgresStorage) DeleteRefreshToken(ctx context.Context, token string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE token = $1`, token)
+	if err != nil {
+		return fmt.Errorf("delete refresh token: %w", err)
+	}
+	return nil
+}
+
+// DeleteUserRefreshTokens revokes every refresh token the user holds.
+func (s *PostgresStorage) DeleteUserRefreshTokens(ctx context.Context, userID int64) error {
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM refresh_tokens WHERE user_id = $1`, userID,
+	); err != nil {
+		return fmt.Errorf("delete user refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// RevokeToken blacklists an access token by its jti.
+func (s *PostgresStorage) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO revoked_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING`,
+		jti, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	return nil
+}
+
+// IsTokenRevoked reports whether a jti is on the revocation list.
+func (s *PostgresStorage) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	err := s.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`, jti,
+	).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("check token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// AddItem inserts an item and returns its assigned ID. A soft-deleted
+// item with the same name is purged first so the unique (user_id, name)
+// constraint does not block reusing the name before the reaper runs.
+func (s *PostgresStorage) AddItem(ctx context.Context, item *models.Item) (int64, error) {
+	var id int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		id, err = s.addItemTx(ctx, tx, item)
+		if err != nil {
+			return fmt.Errorf("add item: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// AddItems inserts all items in a single transaction so a failure
+// rolls back the whole batch.
+func (s *PostgresStorage) AddItems(ctx context.Context, items []*models.Item) ([]int64, error) {
+	ids := make([]int64, 0, len(items))
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, item := range items {
+			id, err := s.addItemTx(ctx, tx, item)
+			if err != nil {
+				return fmt.Errorf("add item %q: %w", item.Name, err)
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// addItemTx performs the soft-delete pre-purge and the insert for one
+// item inside tx.
+func (s *PostgresStorage) addItemTx(ctx context.Context, tx *sql.Tx, item *models.Item) (int64, error) {
+	meta, err := metadataToJSON(item.Metadata)
+	if err != nil {
+		return 0, err
+	}
+	tags, err := tagsToJSON(item.Tags)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM items WHERE user_id = $1 AND name = $2 AND deleted_at IS NOT NULL`,
+		item.UserID, item.Name,
+	); err != nil {
+		return 0, err
+	}
+	var id int64
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO items (user_id, name, type, data, metadata, tags, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		item.UserID, item.Name, item.Type, item.Data, meta, tags, nullTime(item.ExpiresAt),
+	).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, ErrItemExists
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetItem fetches a single item owned by userID.
+func (s *PostgresStorage) GetItem(ctx context.Context, userID, itemID int64) (*models.Item, error) {
+	return s.scanItem(s.db.QueryRowContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = $1 AND id = $2 AND deleted_at IS NULL`,
+		userID, itemID,
+	))
+}
+
+// GetItemByName fetches a single item owned by userID by its name.
+func (s *PostgresStorage) GetItemByName(ctx context.Context, userID int64, name string) (*models.Item, error) {
+	return s.scanItem(s.db.QueryRowContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = $1 AND name = $2 AND deleted_at IS NULL`,
+		userID, name,
+	))
+}
+
+// ListItems returns one page of items owned by userID along with the
+// total matching item count; the default stable (created_at, id)
+// order can be overridden with sortBy and order. Both the metadata
+// filter and the tags filter are applied with JSONB containment, so
+// only items matching every given pair and tag are returned.
+func (s *PostgresStorage) ListItems(ctx context.Context, userID, limit, offset int64, filter map[string]string, tags []string, sortBy, order string) ([]*models.Item, int64, error) {
+	orderBy, err := orderClause(sortBy, order)
+	if err != nil {
+		return nil, 0, err
+	}
+	where := `WHERE user_id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now())`
+	countArgs := []any{userID}
+	if len(filter) > 0 {
+		filterJSON, err := metadataToJSON(filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		where += fmt.Sprintf(` AND metadata @> $%d`, len(countArgs)+1)
+		countArgs = append(countArgs, filterJSON)
+	}
+	if len(tags) > 0 {
+		tagsJSON, err := tagsToJSON(tags)
+		if err != nil {
+			return nil, 0, err
+		}
+		where += fmt.Sprintf(` AND tags @> $%d`, len(countArgs)+1)
+		countArgs = append(countArgs, tagsJSON)
+	}
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT count(*) FROM items `+where, countArgs...,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count items: %w", err)
+	}
+
+	args := append([]any{}, countArgs...)
+	query := fmt.Sprintf(`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items %s %s OFFSET $%d`, where, orderBy, len(args)+1)
+	args = append(args, offset)
+	if limit > 0 {
+		query += fmt.Sprintf(` LIMIT $%d`, len(args)+1)
+		args = append(args, limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.Item
+	for rows.Next() {
+		item, err := s.scanItem(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list items: %w", err)
+	}
+	return items, total, nil
+}
+
+// CountItemsByType returns per-type statistics for the user's live
+// items in a single GROUP BY query.
+func (s *PostgresStorage) CountItemsByType(ctx context.Context, userID int64) (map[string]TypeStats, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT type, count(*), coalesce(sum(length(data)), 0), min(created_at), max(created_at)
+		 FROM items
+		 WHERE user_id = $1 AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > now())
+		 GROUP BY type`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("count items by type: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]TypeStats)
+	for rows.Next() {
+		var typ string
+		var st TypeStats
+		if err := rows.Scan(&typ, &st.Count, &st.TotalSize, &st.Oldest, &st.Newest); err != nil {
+			return nil, fmt.Errorf("scan type stats: %w", err)
+		}
+		out[typ] = st
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("count items by type: %w", err)
+	}
+	return out, nil
+}
+
+// ItemsSince returns items owned by userID changed after since.
+// Soft-deleted items are included as tombstones so clients can drop
+// them from local caches.
+func (s *PostgresStorage) ItemsSince(ctx context.Context, userID int64, since time.Time) ([]*models.Item, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = $1 AND updated_at > $2
+		   AND (deleted_at IS NOT NULL OR expires_at IS NULL OR expires_at > now())
+		 ORDER BY created_at, id`,
+		userID, since,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("items since: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.Item
+	for rows.Next() {
+		item, err := s.scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("items since: %w", err)
+	}
+	return items, nil
+}
+
+// ItemsSincePage returns one keyset page of items changed after since,
+// ordered by (updated_at, id) and starting strictly after the
+// (afterUpdated, afterID) cursor. One extra row is fetched to learn
+// whether more pages remain.
+func (s *PostgresStorage) ItemsSincePage(ctx context.Context, userID int64, since, afterUpdated time.Time, afterID, limit int64) ([]*models.Item, bool, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = $1 AND updated_at > $2
+		   AND (updated_at > $3 OR (updated_at = $3 AND id > $4))
+		   AND (deleted_at IS NOT NULL OR expires_at IS NULL OR expires_at > now())
+		 ORDER BY updated_at, id LIMIT $5`,
+		userID, since, afterUpdated, afterID, limit+1,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("items since page: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.Item
+	for rows.Next() {
+		item, err := s.scanItem(rows)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("items since page: %w", err)
+	}
+	more := int64(len(items)) > limit
+	if more {
+		items = items[:limit]
+	}
+	return items, more, nil
+}
+
+// SearchItemsByName returns items owned by userID whose name contains
+// query, case-insensitively.
+func (s *PostgresStorage) SearchItemsByName(ctx context.Context, userID int64, query string) ([]*models.Item, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = $1 AND name ILIKE '%' || $2 || '%'
+		   AND deleted_at IS NULL
+		   AND (expires_at IS NULL OR expires_at > now()) ORDER BY name`,
+		userID, query,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.Item
+	for rows.Next() {
+		item, err := s.scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search items: %w", err)
+	}
+	return items, nil
+}
+
+// UpdateItem overwrites an item's payload and metadata, bumping its
+// version.
+func (s *PostgresStorage) UpdateItem(ctx context.Context, item *models.Item) error {
+	meta, err := metadataToJSON(item.Metadata)
+	if err != nil {
+		return err
+	}
+	tags, err := tagsToJSON(item.Tags)
+	if err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := s.archiveItemVersion(ctx, tx, item.UserID, item.ID); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx,
+			`UPDATE items SET data = $1, metadata = $2, tags = $3, version = version + 1, updated_at = now()
+			 WHERE user_id = $4 AND id = $5 AND deleted_at IS NULL`,
+			item.Data, meta, tags, item.UserID, item.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("update item: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("update item: %w", err)
+		} else if n == 0 {
+			return ErrItemNotFound
+		}
+		return nil
+	})
+}
+
+// archiveItemVersion copies the item's current row into item_versions
+// inside the caller's transaction, so the value being overwritten can
+// be restored later.
+func (s *PostgresStorage) archiveItemVersion(ctx context.Context, tx *sql.Tx, userID, itemID int64) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO item_versions (item_id, user_id, version, data, metadata, tags)
+		 SELECT id, user_id, version, data, metadata, tags FROM items
+		 WHERE user_id = $1 AND id = $2 AND deleted_at IS NULL`,
+		userID, itemID,
+	)
+	if err != nil {
+		return fmt.Errorf("archive item version: %w", err)
+	}
+	return nil
+}
+
+// ListItemVersions returns archived prior values of an item, newest
+// first.
+func (s *PostgresStorage) ListItemVersions(ctx context.Context, userID, itemID int64) ([]*models.ItemVersion, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, item_id, user_id, version, data, metadata, tags, archived_at
+		 FROM item_versions WHERE user_id = $1 AND item_id = $2
+		 ORDER BY version DESC`,
+		userID, itemID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list item versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*models.ItemVersion
+	for rows.Next() {
+		var v models.ItemVersion
+		var meta, tags []byte
+		if err := rows.Scan(&v.ID, &v.ItemID, &v.UserID, &v.Version, &v.Data, &meta, &tags, &v.ArchivedAt); err != nil {
+			return nil, fmt.Errorf("list item versions: %w", err)
+		}
+		if v.Metadata, err = jsonToMetadata(meta); err != nil {
+			return nil, err
+		}
+		if v.Tags, err = jsonToTags(tags); err != nil {
+			return nil, err
+		}
+		versions = append(versions, &v)
+	}
+	return versions, rows.Err()
+}
+
+// UpdateItemByName updates the user's item with the given name inside
+// one transaction: the stored version is read with a row lock, checked
+// against item.Version (when non-zero) and only then bumped.
+func (s *PostgresStorage) UpdateItemByName(ctx context.Context, item *models.Item) error {
+	meta, err := metadataToJSON(item.Metadata)
+	if err != nil {
+		return err
+	}
+	tags, err := tagsToJSON(item.Tags)
+	if err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var id, version int64
+		err := tx.QueryRowContext(ctx,
+			`SELECT id, version FROM items WHERE user_id = $1 AND name = $2 AND deleted_at IS NULL FOR UPDATE`,
+			item.UserID, item.Name,
+		).Scan(&id, &version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("update item by name: %w", err)
+		}
+		if item.Version != 0 && item.Version != version {
+			return ErrVersionConflict
+		}
+		if err := s.archiveItemVersion(ctx, tx, item.UserID, id); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE items SET data = $1, metadata = $2, tags = $3, version = version + 1, updated_at = now()
+			 WHERE id = $4`,
+			item.Data, meta, tags, id,
+		); err != nil {
+			return fmt.Errorf("update item by name: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteExpiredItems removes items whose expiry has passed.
+func (s *PostgresStorage) DeleteExpiredItems(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM items WHERE expires_at IS NOT NULL AND expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired items: %w", err)
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete expired items: %w", err)
+	}
+	return removed, nil
+}
+
+// DeleteItem soft-deletes an item owned by userID. The row stays in
+// place as a tombstone (with its version bumped so sync picks it up)
+// until PurgeDeleted or PurgeItem removes it for good.
+func (s *PostgresStorage) DeleteItem(ctx context.Context, userID, itemID int64) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE items SET deleted_at = now(), version = version + 1, updated_at = now()
+		 WHERE user_id = $1 AND id = $2 AND deleted_at IS NULL`,
+		userID, itemID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete item: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("delete item: %w", err)
+	} else if n == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// DeleteItems soft-deletes the named items in one transaction and
+// returns the names that had no live item.
+func (s *PostgresStorage) DeleteItems(ctx context.Context, userID int64, names []string) ([]string, error) {
+	var missing []string
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, name := range names {
+			res, err := tx.ExecContext(ctx,
+				`UPDATE items SET deleted_at = now(), version = version + 1, updated_at = now()
+				 WHERE user_id = $1 AND name = $2 AND deleted_at IS NULL`,
+				userID, name,
+			)
+			if err != nil {
+				return fmt.Errorf("delete item %q: %w", name, err)
+			}
+			if n, err := res.RowsAffected(); err != nil {
+				return fmt.Errorf("delete item %q: %w", name, err)
+			} else if n == 0 {
+				missing = append(missing, name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+// AppendAuditEntry records one data-access event.
+func (s *PostgresStorage) AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO audit_log (user_id, action, item_id, ip) VALUES ($1, $2, $3, $4)
+		 RETURNING id, created_at`,
+		entry.UserID, entry.Action, entry.ItemID, entry.IP,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("append audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries returns the user's audit entries, newest first.
+func (s *PostgresStorage) ListAuditEntries(ctx context.Context, userID, limit int64) ([]*models.AuditEntry, error) {
+	query := `SELECT id, user_id, action, item_id, ip, created_at FROM audit_log
+		  WHERE user_id = $1 ORDER BY id DESC`
+	args := []any{userID}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.AuditEntry
+	for rows.Next() {
+		entry := &models.AuditEntry{}
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.Action, &entry.ItemID, &entry.IP, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("list audit entries: %w", err)
+		}
+		out = append(out, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	return out, nil
+}
+
+// ListTrash returns the user's soft-deleted items, most recently
+// deleted first.
+func (s *PostgresStorage) ListTrash(ctx context.Context, userID int64) ([]*models.Item, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = $1 AND deleted_at IS NOT NULL
+		 ORDER BY deleted_at DESC, id DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.Item
+	for rows.Next() {
+		item, err := s.scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	return items, nil
+}
+
+// RestoreItem clears a soft-deleted item's tombstone and bumps its
+// version.
+func (s *PostgresStorage) RestoreItem(ctx context.Context, userID, itemID int64) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE items SET deleted_at = NULL, version = version + 1, updated_at = now()
+		 WHERE user_id = $1 AND id = $2 AND deleted_at IS NOT NULL`,
+		userID, itemID,
+	)
+	if err != nil {
+		return fmt.Errorf("restore item: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("restore item: %w", err)
+	} else if n == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// PurgeDeleted hard-deletes items soft-deleted at or before olderThan.
+// A userID of 0 purges across all users (used by the background
+// reaper). Archived versions go with the items via the foreign key.
+func (s *PostgresStorage) PurgeDeleted(ctx context.Context, userID int64, olderThan time.Time) (int64, error) {
+	query := `DELETE FROM items WHERE deleted_at IS NOT NULL AND deleted_at <= $1`
+	args := []any{olderThan}
+	if userID != 0 {
+		query += ` AND user_id = $2`
+		args = append(args, userID)
+	}
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("purge deleted items: %w", err)
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("purge deleted items: %w", err)
+	}
+	return removed, nil
+}
+
+// PurgeItem hard-deletes one item by ID, or by name when itemID is
+// zero, whether or not it was soft-deleted first.
+func (s *PostgresStorage) PurgeItem(ctx context.Context, userID, itemID int64, name string) error {
+	var res sql.Result
+	var err error
+	if itemID != 0 {
+		res, err = s.db.ExecContext(ctx, `DELETE FROM items WHERE user_id = $1 AND id = $2`, userID, itemID)
+	} else {
+		res, err = s.db.ExecContext(ctx, `DELETE FROM items WHERE user_id = $1 AND name = $2`, userID, name)
+	}
+	if err != nil {
+		return fmt.Errorf("purge item: %w", err)
+	}
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("purge item: %w", err)
+	}
+	if removed == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// DBStats exposes connection pool statistics for monitoring.
+func (s *PostgresStorage) DBStats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// Ping reports whether the database is reachable.
+func (s *PostgresStorage) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping database: %w", err)
+	}
+	return nil
+}
+
+// Close releases the underlying connection pool.
+func (s *PostgresStorage) Close() error {
+	return s.db.Close()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func (s *PostgresStorage) scanItem(row rowScanner) (*models.Item, error) {
+	item := &models.Item{}
+	var meta, tags []byte
+	var expires, deleted sql.NullTime
+	err := row.Scan(&item.ID, &item.UserID, &item.Name, &item.Type, &item.Data,
+		&meta, &tags, &item.Version, &expires, &deleted, &item.CreatedAt, &item.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan item: %w", err)
+	}
+	item.Metadata, err = jsonToMetadata(meta)
+	if err != nil {
+		return nil, err
+	}
+	item.Tags, err = jsonToTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if expires.Valid {
+		item.ExpiresAt = expires.Time
+	}
+	if deleted.Valid {
+		item.DeletedAt = deleted.Time
+	}
+	return item, nil
+}
+
+// nullTime maps the zero time to SQL NULL for nullable columns.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// metadataToJSON marshals item metadata for the JSONB column. A nil map
+// is stored as the empty object so reads never see SQL NULL.
+func metadataToJSON(meta map[string]string) ([]byte, error) {
+	if meta == nil {
+		meta = map[string]string{}
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("marshal metadata: %w", err)
+	}
+	return data, nil
+}
+
+// jsonToMetadata unmarshals the JSONB column back into a map. Empty or
+// NULL input yields an empty map.
+func jsonToMetadata(data []byte) (map[string]string, error) {
+	meta := map[string]string{}
+	if len(data) == 0 {
+		return meta, nil
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("unmarshal metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// tagsToJSON marshals item tags for the JSONB column. Nil is stored as
+// the empty array so containment checks behave.
+func tagsToJSON(tags []string) ([]byte, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("marshal tags: %w", err)
+	}
+	return data, nil
+}
+
+// jsonToTags unmarshals the JSONB tags column. Empty or NULL input
+// yields no tags.
+func jsonToTags(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var tags []string
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("unmarshal tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return tags, nil
+}
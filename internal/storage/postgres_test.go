@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+func TestMetadataJSONRoundTrip(t *testing.T) {
+	meta := map[string]string{"site": "example.com", "note": "work account"}
+
+	data, err := metadataToJSON(meta)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	got, err := jsonToMetadata(data)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(got, meta) {
+		t.Fatalf("round trip mismatch: got %v, want %v", got, meta)
+	}
+}
+
+func TestMetadataJSONEmpty(t *testing.T) {
+	data, err := metadataToJSON(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{}" {
+		t.Fatalf("nil map marshaled to %q, want {}", data)
+	}
+
+	for _, in := range [][]byte{nil, {}, []byte("{}")} {
+		got, err := jsonToMetadata(in)
+		if err != nil {
+			t.Fatalf("unmarshal %q: %v", in, err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("unmarshal %q = %v, want empty map", in, got)
+		}
+	}
+}
+
+func TestMetadataJSONMalformed(t *testing.T) {
+	if _, err := jsonToMetadata([]byte("not json")); err == nil {
+		t.Fatal("expected error for malformed JSON")
+	}
+}
+
+// TestPostgresMetadataSurvivesReadBack needs a real database and only
+// runs when TEST_DATABASE_DSN is set.
+func TestPostgresMetadataSurvivesReadBack(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+	s, err := NewPostgresStorage(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	user, err := s.CreateUser(context.Background(), "meta-test-user", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := map[string]string{"site": "example.com"}
+	id, err := s.AddItem(context.Background(), &models.Item{UserID: user.ID, Name: "mail", Type: models.TypeText, Data: []byte("x"), Metadata: meta})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetItem(context.Background(), user.ID, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Metadata, meta) {
+		t.Fatalf("metadata lost on read back: got %v, want %v", got.Metadata, meta)
+	}
+}
+
+// TestPostgresListItemsPagination needs a real database and only runs
+// when TEST_DATABASE_DSN is set.
+func TestPostgresListItemsPagination(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+	s, err := NewPostgresStorage(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	user, err := s.CreateUser(context.Background(), "page-test-user", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		_, err := s.AddItem(context.Background(), &models.Item{UserID: user.ID, Name: fmt.Sprintf("item-%d", i), Type: models.TypeText, Data: []byte("x")})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seen := map[int64]bool{}
+	for offset := int64(0); offset < 5; offset += 2 {
+		items, total, err := s.ListItems(context.Background(), user.ID, 2, offset, nil, nil, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != 5 {
+			t.Fatalf("total = %d, want 5", total)
+		}
+		for _, item := range items {
+			if seen[item.ID] {
+				t.Fatalf("item %d returned on two pages", item.ID)
+			}
+			seen[item.ID] = true
+		}
+	}
+	if len(seen) != 5 {
+		t.Fatalf("pagination covered %d items, want 5", len(seen))
+	}
+
+	// Past the last page: empty but not an error.
+	items, _, err := s.ListItems(context.Background(), user.ID, 2, 100, nil, nil, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Fatalf("expected empty page past the end, got %d items", len(items))
+	}
+}
+
+func TestPingWithRetryRecoversAfterFailures(t *testing.T) {
+	attempts := 0
+	ping := func() error {
+		attempts++
+		if attempts <= 2 {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	}
+	if err := pingWithRetry(ping, time.Second, time.Millisecond); err != nil {
+		t.Fatalf("retry did not recover: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("ping called %d times, want 3", attempts)
+	}
+}
+
+func TestPingWithRetryGivesUpAtTimeout(t *testing.T) {
+	attempts := 0
+	ping := func() error {
+		attempts++
+		return fmt.Errorf("connection refused")
+	}
+	begin := time.Now()
+	err := pingWithRetry(ping, 50*time.Millisecond, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected error when the database never comes up")
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Fatalf("retry ran for %v, want well under the 50ms budget plus slack", elapsed)
+	}
+	if attempts < 2 {
+		t.Fatalf("ping called %d times, want at least 2", attempts)
+	}
+}
@@ -0,0 +1,936 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// SQLiteStorage implements Storage on top of a local SQLite file, for
+// single-user or offline deployments that should not need Postgres.
+type SQLiteStorage struct {
+	db *sql.DB
+}
+
+// NewSQLiteStorage opens (and creates if necessary) the database file
+// at path and initializes the schema.
+func NewSQLiteStorage(path string) (*SQLiteStorage, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	// SQLite handles one writer at a time; a single connection avoids
+	// SQLITE_BUSY errors under concurrent use.
+	db.SetMaxOpenConns(1)
+	s := &SQLiteStorage{db: db}
+	if err := s.MigrateUp(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// withTx runs fn inside a transaction, committing when it returns nil
+// and rolling back otherwise. Every operation touching more than one
+// table (or more than one row dependently) goes through it, so a crash
+// mid-operation cannot leave half the change behind.
+func (s *SQLiteStorage) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreateUser inserts a new user and returns it with the assigned ID.
+func (s *SQLiteStorage) CreateUser(ctx context.Context, login, passwordHash string) (*models.User, error) {
+	now := time.Now().UTC()
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO users (login, password_hash, created_at) VALUES (?, ?, ?)`,
+		login, passwordHash, now,
+	)
+	if err != nil {
+		if isSQLiteUniqueViolation(err) {
+			return nil, ErrUserExists
+		}
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	return &models.User{ID: id, Login: login, PasswordHash: passwordHash, CreatedAt: now}, nil
+}
+
+// isSQLiteUniqueViolation reports whether err is the driver signalling
+// a UNIQUE constraint violation, which is how duplicate logins and
+// duplicate (user_id, name) item pairs surface.
+func isSQLiteUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+}
+
+// GetUserByLogin fetches a user by login name.
+func (s *SQLiteStorage) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	return s.scanUser(s.db.QueryRowContext(ctx,
+		`SELECT id, login, password_hash, created_at, last_login_at, last_login_ip FROM users WHERE login = ?`,
+		login,
+	))
+}
+
+// GetUserByID fetches a user by ID.
+func (s *SQLiteStorage) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
+	return s.scanUser(s.db.QueryRowContext(ctx,
+		`SELECT id, login, password_hash, created_at, last_login_at, last_login_ip FROM users WHERE id = ?`,
+		id,
+	))
+}
+
+// scanUser reads one user row, mapping the nullable last-login column
+// onto the model's zero value.
+func (s *SQLiteStorage) scanUser(row *sql.Row) (*models.User, error) {
+	user := &models.User{}
+	var lastLogin sql.NullTime
+	err := row.Scan(&user.ID, &user.Login, &user.PasswordHash, &user.CreatedAt, &lastLogin, &user.LastLoginIP)
+	if err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	if lastLogin.Valid {
+		user.LastLoginAt = lastLogin.Time
+	}
+	return user, nil
+}
+
+// UpdateUserLastLogin stamps the user's most recent successful login
+// with the current time and the caller's address.
+func (s *SQLiteStorage) UpdateUserLastLogin(ctx context.Context, userID int64, ip string) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET last_login_at = ?, last_login_ip = ? WHERE id = ?`,
+		time.Now().UTC(), ip, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("update user last login: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("update user last login: %w", err)
+	} else if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateUserPassword replaces the user's stored password hash.
+func (s *SQLiteStorage) UpdateUserPassword(ctx context.Context, userID int64, passwordHash string) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET password_hash = ? WHERE id = ?`,
+		passwordHash, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("update user password: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("update user password: %w", err)
+	} else if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// DeleteUser permanently removes the user and everything they own:
+// items, archived versions, refresh tokens and audit entries.
+func (s *SQLiteStorage) DeleteUser(ctx context.Context, userID int64) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM item_versions WHERE user_id = ?`, userID); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM items WHERE user_id = ?`, userID); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = ?`, userID); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM audit_log WHERE user_id = ?`, userID); err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, userID)
+		if err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		removed, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("delete user: %w", err)
+		}
+		if removed == 0 {
+			return ErrUserNotFound
+		}
+		return nil
+	})
+}
+
+// CreateRefreshToken records an issued refresh token.
+func (s *SQLiteStorage) CreateRefreshToken(ctx context.Context, userID int64, token string, expiresAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO refresh_tokens (token, user_id, expires_at) VALUES (?, ?, ?)`,
+		token, userID, expiresAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshToken fetches a refresh token record.
+func (s *SQLiteStorage) GetRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	rt := &models.RefreshToken{}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT token, user_id, expires_at FROM refresh_tokens WHERE token = ?`,
+		token,
+	).Scan(&rt.Token, &rt.UserID, &rt.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get refresh token: %w", err)
+	}
+	return rt, nil
+}
+
+// DeleteRefreshToken revokes a refresh token.
+func (s *SQLiteStorage) DeleteRefreshToken(ctx context.Context, token string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE token = ?`, token)
+	if err != nil {
+		return fmt.Errorf("delete refresh token: %w", err)
+	}
+	return nil
+}
+
+// DeleteUserRefreshTokens revokes every refresh token the user holds.
+func (s *SQLiteStorage) DeleteUserRefreshTokens(ctx context.Context, userID int64) error {
+	if _, err := s.db.ExecContext(ctx,
+		`DELETE FROM refresh_tokens WHERE user_id = ?`, userID,
+	); err != nil {
+		return fmt.Errorf("delete user refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// RevokeToken blacklists an access token by its jti.
+func (s *SQLiteStorage) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO revoked_tokens (jti, expires_at) VALUES (?, ?)`,
+		jti, expiresAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	return nil
+}
+
+// IsTokenRevoked reports whether a jti is on the revocation list.
+func (s *SQLiteStorage) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT count(*) FROM revoked_tokens WHERE jti = ?`, jti,
+	).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("check token revocation: %w", err)
+	}
+	return count > 0, nil
+}
+
+// AddItem inserts an item and returns its assigned ID. A soft-deleted
+// item with the same name is purged first so the unique (user_id, name)
+// constraint does not block reusing the name before the reaper runs.
+func (s *SQLiteStorage) AddItem(ctx context.Context, item *models.Item) (int64, error) {
+	var id int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		id, err = s.addItemTx(ctx, tx, item)
+		if err != nil {
+			return fmt.Errorf("add item: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// AddItems inserts all items in a single transaction so a failure
+// rolls back the whole batch.
+func (s *SQLiteStorage) AddItems(ctx context.Context, items []*models.Item) ([]int64, error) {
+	ids := make([]int64, 0, len(items))
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, item := range items {
+			id, err := s.addItemTx(ctx, tx, item)
+			if err != nil {
+				return fmt.Errorf("add item %q: %w", item.Name, err)
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// addItemTx performs the soft-delete pre-purge and the insert for one
+// item inside tx.
+func (s *SQLiteStorage) addItemTx(ctx context.Context, tx *sql.Tx, item *models.Item) (int64, error) {
+	meta, err := metadataToJSON(item.Metadata)
+	if err != nil {
+		return 0, err
+	}
+	tags, err := tagsToJSON(item.Tags)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM item_versions WHERE item_id IN
+		   (SELECT id FROM items WHERE user_id = ? AND name = ? AND deleted_at IS NOT NULL)`,
+		item.UserID, item.Name,
+	); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM items WHERE user_id = ? AND name = ? AND deleted_at IS NOT NULL`,
+		item.UserID, item.Name,
+	); err != nil {
+		return 0, err
+	}
+	now := time.Now().UTC()
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO items (user_id, name, type, data, metadata, tags, expires_at, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		item.UserID, item.Name, item.Type, item.Data, string(meta), string(tags),
+		nullTime(item.ExpiresAt), now, now,
+	)
+	if err != nil {
+		if isSQLiteUniqueViolation(err) {
+			return 0, ErrItemExists
+		}
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// GetItem fetches a single item owned by userID.
+func (s *SQLiteStorage) GetItem(ctx context.Context, userID, itemID int64) (*models.Item, error) {
+	return scanSQLiteItem(s.db.QueryRowContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = ? AND id = ? AND deleted_at IS NULL`,
+		userID, itemID,
+	))
+}
+
+// GetItemByName fetches a single item owned by userID by its name.
+func (s *SQLiteStorage) GetItemByName(ctx context.Context, userID int64, name string) (*models.Item, error) {
+	return scanSQLiteItem(s.db.QueryRowContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = ? AND name = ? AND deleted_at IS NULL`,
+		userID, name,
+	))
+}
+
+// ListItems returns one page of items owned by userID along with the
+// total matching item count; the default stable (created_at, id)
+// order can be overridden with sortBy and order. A non-empty filter
+// matches key/value pairs of the metadata column via json_each, so
+// keys containing JSON path syntax ('.', '"') behave like any other;
+// a non-empty tags slice requires each tag to appear in the tags
+// array.
+func (s *SQLiteStorage) ListItems(ctx context.Context, userID, limit, offset int64, filter map[string]string, tags []string, sortBy, order string) ([]*models.Item, int64, error) {
+	orderBy, err := orderClause(sortBy, order)
+	if err != nil {
+		return nil, 0, err
+	}
+	where := `WHERE user_id = ? AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > ?)`
+	countArgs := []any{userID, time.Now().UTC()}
+	for key, value := range filter {
+		where += ` AND EXISTS (SELECT 1 FROM json_each(items.metadata) WHERE json_each.key = ? AND json_each.value = ?)`
+		countArgs = append(countArgs, key, value)
+	}
+	for _, tag := range tags {
+		where += ` AND EXISTS (SELECT 1 FROM json_each(items.tags) WHERE json_each.value = ?)`
+		countArgs = append(countArgs, tag)
+	}
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT count(*) FROM items `+where, countArgs...,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count items: %w", err)
+	}
+
+	if limit <= 0 {
+		// SQLite requires LIMIT when OFFSET is used; -1 means no limit.
+		limit = -1
+	}
+	args := append(append([]any{}, countArgs...), limit, offset)
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items `+where+` `+orderBy+` LIMIT ? OFFSET ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list items: %w", err)
+	}
+	defer rows.Close()
+
+	items, err := collectSQLiteItems(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// CountItemsByType returns per-type statistics for the user's live
+// items in a single GROUP BY query.
+func (s *SQLiteStorage) CountItemsByType(ctx context.Context, userID int64) (map[string]TypeStats, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT type, count(*), coalesce(sum(length(data)), 0), min(created_at), max(created_at)
+		 FROM items
+		 WHERE user_id = ? AND deleted_at IS NULL AND (expires_at IS NULL OR expires_at > ?)
+		 GROUP BY type`,
+		userID, time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("count items by type: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]TypeStats)
+	for rows.Next() {
+		var typ, oldest, newest string
+		var st TypeStats
+		if err := rows.Scan(&typ, &st.Count, &st.TotalSize, &oldest, &newest); err != nil {
+			return nil, fmt.Errorf("scan type stats: %w", err)
+		}
+		if st.Oldest, err = parseSQLiteTime(oldest); err != nil {
+			return nil, err
+		}
+		if st.Newest, err = parseSQLiteTime(newest); err != nil {
+			return nil, err
+		}
+		out[typ] = st
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("count items by type: %w", err)
+	}
+	return out, nil
+}
+
+// sqliteTimeFormats are the layouts the sqlite3 driver uses to store
+// time.Time values. Aggregate expressions like min(created_at) lose
+// the column's declared type, so their results come back as raw
+// strings the driver no longer converts.
+var sqliteTimeFormats = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05",
+	time.RFC3339Nano,
+}
+
+func parseSQLiteTime(s string) (time.Time, error) {
+	for _, layout := range sqliteTimeFormats {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized sqlite timestamp %q", s)
+}
+
+// ItemsSince returns items owned by userID changed after since.
+// Soft-deleted items are included as tombstones so clients can drop
+// them from local caches.
+func (s *SQLiteStorage) ItemsSince(ctx context.Context, userID int64, since time.Time) ([]*models.Item, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = ? AND updated_at > ?
+		   AND (deleted_at IS NOT NULL OR expires_at IS NULL OR expires_at > ?)
+		 ORDER BY created_at, id`,
+		userID, since.UTC(), time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("items since: %w", err)
+	}
+	defer rows.Close()
+	return collectSQLiteItems(rows)
+}
+
+// ItemsSincePage returns one keyset page of items changed after since,
+// ordered by (updated_at, id) and starting strictly after the
+// (afterUpdated, afterID) cursor. One extra row is fetched to learn
+// whether more pages remain.
+func (s *SQLiteStorage) ItemsSincePage(ctx context.Context, userID int64, since, afterUpdated time.Time, afterID, limit int64) ([]*models.Item, bool, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = ? AND updated_at > ?
+		   AND (updated_at > ? OR (updated_at = ? AND id > ?))
+		   AND (deleted_at IS NOT NULL OR expires_at IS NULL OR expires_at > ?)
+		 ORDER BY updated_at, id LIMIT ?`,
+		userID, since.UTC(), afterUpdated.UTC(), afterUpdated.UTC(), afterID, time.Now().UTC(), limit+1,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("items since page: %w", err)
+	}
+	defer rows.Close()
+	items, err := collectSQLiteItems(rows)
+	if err != nil {
+		return nil, false, err
+	}
+	more := int64(len(items)) > limit
+	if more {
+		items = items[:limit]
+	}
+	return items, more, nil
+}
+
+// SearchItemsByName returns items owned by userID whose name contains
+// query, case-insensitively.
+func (s *SQLiteStorage) SearchItemsByName(ctx context.Context, userID int64, query string) ([]*models.Item, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = ? AND instr(lower(name), lower(?)) > 0
+		   AND deleted_at IS NULL
+		   AND (expires_at IS NULL OR expires_at > ?) ORDER BY name`,
+		userID, query, time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search items: %w", err)
+	}
+	defer rows.Close()
+	return collectSQLiteItems(rows)
+}
+
+// UpdateItem overwrites an item's payload and metadata, bumping its
+// version.
+func (s *SQLiteStorage) UpdateItem(ctx context.Context, item *models.Item) error {
+	meta, err := metadataToJSON(item.Metadata)
+	if err != nil {
+		return err
+	}
+	tags, err := tagsToJSON(item.Tags)
+	if err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := s.archiveItemVersion(ctx, tx, item.UserID, item.ID); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx,
+			`UPDATE items SET data = ?, metadata = ?, tags = ?, version = version + 1, updated_at = ?
+			 WHERE user_id = ? AND id = ? AND deleted_at IS NULL`,
+			item.Data, string(meta), string(tags), time.Now().UTC(), item.UserID, item.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("update item: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("update item: %w", err)
+		} else if n == 0 {
+			return ErrItemNotFound
+		}
+		return nil
+	})
+}
+
+// archiveItemVersion copies the item's current row into item_versions
+// inside the caller's transaction, so the value being overwritten can
+// be restored later.
+func (s *SQLiteStorage) archiveItemVersion(ctx context.Context, tx *sql.Tx, userID, itemID int64) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO item_versions (item_id, user_id, version, data, metadata, tags, archived_at)
+		 SELECT id, user_id, version, data, metadata, tags, ? FROM items
+		 WHERE user_id = ? AND id = ? AND deleted_at IS NULL`,
+		time.Now().UTC(), userID, itemID,
+	)
+	if err != nil {
+		return fmt.Errorf("archive item version: %w", err)
+	}
+	return nil
+}
+
+// ListItemVersions returns archived prior values of an item, newest
+// first.
+func (s *SQLiteStorage) ListItemVersions(ctx context.Context, userID, itemID int64) ([]*models.ItemVersion, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, item_id, user_id, version, data, metadata, tags, archived_at
+		 FROM item_versions WHERE user_id = ? AND item_id = ?
+		 ORDER BY version DESC`,
+		userID, itemID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list item versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*models.ItemVersion
+	for rows.Next() {
+		var v models.ItemVersion
+		var meta, tags []byte
+		if err := rows.Scan(&v.ID, &v.ItemID, &v.UserID, &v.Version, &v.Data, &meta, &tags, &v.ArchivedAt); err != nil {
+			return nil, fmt.Errorf("list item versions: %w", err)
+		}
+		if v.Metadata, err = jsonToMetadata(meta); err != nil {
+			return nil, err
+		}
+		if v.Tags, err = jsonToTags(tags); err != nil {
+			return nil, err
+		}
+		versions = append(versions, &v)
+	}
+	return versions, rows.Err()
+}
+
+// UpdateItemByName updates the user's item with the given name inside
+// one transaction: the stored version is read, checked against
+// item.Version (when non-zero) and only then bumped.
+func (s *SQLiteStorage) UpdateItemByName(ctx context.Context, item *models.Item) error {
+	meta, err := metadataToJSON(item.Metadata)
+	if err != nil {
+		return err
+	}
+	tags, err := tagsToJSON(item.Tags)
+	if err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var id, version int64
+		err := tx.QueryRowContext(ctx,
+			`SELECT id, version FROM items WHERE user_id = ? AND name = ? AND deleted_at IS NULL`,
+			item.UserID, item.Name,
+		).Scan(&id, &version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("update item by name: %w", err)
+		}
+		if item.Version != 0 && item.Version != version {
+			return ErrVersionConflict
+		}
+		if err := s.archiveItemVersion(ctx, tx, item.UserID, id); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE items SET data = ?, metadata = ?, tags = ?, version = version + 1, updated_at = ?
+			 WHERE id = ?`,
+			item.Data, string(meta), string(tags), time.Now().UTC(), id,
+		); err != nil {
+			return fmt.Errorf("update item by name: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteExpiredItems removes items whose expiry has passed, along with
+// their archived versions: a self-destructing secret must not live on
+// in the history table.
+func (s *SQLiteStorage) DeleteExpiredItems(ctx context.Context, now time.Time) (int64, error) {
+	where := `expires_at IS NOT NULL AND expires_at <= ?`
+	var removed int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM item_versions WHERE item_id IN (SELECT id FROM items WHERE `+where+`)`,
+			now.UTC(),
+		); err != nil {
+			return fmt.Errorf("delete expired items: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM items WHERE `+where, now.UTC())
+		if err != nil {
+			return fmt.Errorf("delete expired items: %w", err)
+		}
+		removed, err = res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("delete expired items: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// DeleteItem soft-deletes an item owned by userID. The row stays in
+// place as a tombstone (with its version bumped so sync picks it up)
+// until PurgeDeleted or PurgeItem removes it for good.
+func (s *SQLiteStorage) DeleteItem(ctx context.Context, userID, itemID int64) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE items SET deleted_at = ?, version = version + 1, updated_at = ?
+		 WHERE user_id = ? AND id = ? AND deleted_at IS NULL`,
+		time.Now().UTC(), time.Now().UTC(), userID, itemID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete item: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("delete item: %w", err)
+	} else if n == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// DeleteItems soft-deletes the named items in one transaction and
+// returns the names that had no live item.
+func (s *SQLiteStorage) DeleteItems(ctx context.Context, userID int64, names []string) ([]string, error) {
+	var missing []string
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+		for _, name := range names {
+			res, err := tx.ExecContext(ctx,
+				`UPDATE items SET deleted_at = ?, version = version + 1, updated_at = ?
+				 WHERE user_id = ? AND name = ? AND deleted_at IS NULL`,
+				now, now, userID, name,
+			)
+			if err != nil {
+				return fmt.Errorf("delete item %q: %w", name, err)
+			}
+			if n, err := res.RowsAffected(); err != nil {
+				return fmt.Errorf("delete item %q: %w", name, err)
+			} else if n == 0 {
+				missing = append(missing, name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+// AppendAuditEntry records one data-access event.
+func (s *SQLiteStorage) AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	now := time.Now().UTC()
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO audit_log (user_id, action, item_id, ip, created_at) VALUES (?, ?, ?, ?, ?)`,
+		entry.UserID, entry.Action, entry.ItemID, entry.IP, now,
+	)
+	if err != nil {
+		return fmt.Errorf("append audit entry: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("append audit entry: %w", err)
+	}
+	entry.ID = id
+	entry.CreatedAt = now
+	return nil
+}
+
+// ListAuditEntries returns the user's audit entries, newest first.
+func (s *SQLiteStorage) ListAuditEntries(ctx context.Context, userID, limit int64) ([]*models.AuditEntry, error) {
+	query := `SELECT id, user_id, action, item_id, ip, created_at FROM audit_log
+		  WHERE user_id = ? ORDER BY id DESC`
+	args := []any{userID}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.AuditEntry
+	for rows.Next() {
+		entry := &models.AuditEntry{}
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.Action, &entry.ItemID, &entry.IP, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("list audit entries: %w", err)
+		}
+		out = append(out, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	return out, nil
+}
+
+// ListTrash returns the user's soft-deleted items, most recently
+// deleted first.
+func (s *SQLiteStorage) ListTrash(ctx context.Context, userID int64) ([]*models.Item, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, user_id, name, type, data, metadata, tags, version, expires_at, deleted_at, created_at, updated_at
+		 FROM items WHERE user_id = ? AND deleted_at IS NOT NULL
+		 ORDER BY deleted_at DESC, id DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	defer rows.Close()
+	return collectSQLiteItems(rows)
+}
+
+// RestoreItem clears a soft-deleted item's tombstone and bumps its
+// version.
+func (s *SQLiteStorage) RestoreItem(ctx context.Context, userID, itemID int64) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE items SET deleted_at = NULL, version = version + 1, updated_at = ?
+		 WHERE user_id = ? AND id = ? AND deleted_at IS NOT NULL`,
+		time.Now().UTC(), userID, itemID,
+	)
+	if err != nil {
+		return fmt.Errorf("restore item: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("restore item: %w", err)
+	} else if n == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// PurgeDeleted hard-deletes items soft-deleted at or before olderThan,
+// along with their archived versions. A userID of 0 purges across all
+// users (used by the background reaper).
+func (s *SQLiteStorage) PurgeDeleted(ctx context.Context, userID int64, olderThan time.Time) (int64, error) {
+	where := `deleted_at IS NOT NULL AND deleted_at <= ?`
+	args := []any{olderThan.UTC()}
+	if userID != 0 {
+		where += ` AND user_id = ?`
+		args = append(args, userID)
+	}
+	var removed int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM item_versions WHERE item_id IN (SELECT id FROM items WHERE `+where+`)`,
+			args...,
+		); err != nil {
+			return fmt.Errorf("purge deleted items: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM items WHERE `+where, args...)
+		if err != nil {
+			return fmt.Errorf("purge deleted items: %w", err)
+		}
+		removed, err = res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("purge deleted items: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// PurgeItem hard-deletes one item by ID, or by name when itemID is
+// zero, whether or not it was soft-deleted first.
+func (s *SQLiteStorage) PurgeItem(ctx context.Context, userID, itemID int64, name string) error {
+	where := `user_id = ? AND id = ?`
+	args := []any{userID, itemID}
+	if itemID == 0 {
+		where = `user_id = ? AND name = ?`
+		args = []any{userID, name}
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM item_versions WHERE item_id IN (SELECT id FROM items WHERE `+where+`)`,
+			args...,
+		); err != nil {
+			return fmt.Errorf("purge item: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM items WHERE `+where, args...)
+		if err != nil {
+			return fmt.Errorf("purge item: %w", err)
+		}
+		removed, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("purge item: %w", err)
+		}
+		if removed == 0 {
+			return ErrItemNotFound
+		}
+		return nil
+	})
+}
+
+// DBStats exposes connection pool statistics for monitoring.
+func (s *SQLiteStorage) DBStats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// Ping reports whether the database is reachable.
+func (s *SQLiteStorage) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping database: %w", err)
+	}
+	return nil
+}
+
+// Close releases the database handle.
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
+
+func scanSQLiteItem(row rowScanner) (*models.Item, error) {
+	item := &models.Item{}
+	var meta, tags string
+	var expires, deleted sql.NullTime
+	err := row.Scan(&item.ID, &item.UserID, &item.Name, &item.Type, &item.Data,
+		&meta, &tags, &item.Version, &expires, &deleted, &item.CreatedAt, &item.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scan item: %w", err)
+	}
+	item.Metadata, err = jsonToMetadata([]byte(meta))
+	if err != nil {
+		return nil, err
+	}
+	item.Tags, err = jsonToTags([]byte(tags))
+	if err != nil {
+		return nil, err
+	}
+	if expires.Valid {
+		item.ExpiresAt = expires.Time
+	}
+	if deleted.Valid {
+		item.DeletedAt = deleted.Time
+	}
+	return item, nil
+}
+
+func collectSQLiteItems(rows *sql.Rows) ([]*models.Item, error) {
+	var items []*models.Item
+	for rows.Next() {
+		item, err := scanSQLiteItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("collect items: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,215 @@
+// Package storage defines the persistence interface for users and
+// items and its database implementations.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+// Sentinel errors returned by Storage implementations so callers can
+// react without inspecting driver-specific errors.
+var (
+	ErrUserExists      = errors.New("user already exists")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrItemNotFound    = errors.New("item not found")
+	ErrItemExists      = errors.New("item already exists")
+	ErrVersionConflict = errors.New("item version conflict")
+
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+)
+
+// Storage is the persistence interface used by the server. All methods
+// scope item access by user ID; implementations must never return
+// another user's data.
+type Storage interface {
+	CreateUser(ctx context.Context, login, passwordHash string) (*models.User, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.User, error)
+	GetUserByID(ctx context.Context, id int64) (*models.User, error)
+	// UpdateUserPassword replaces the user's stored password hash.
+	UpdateUserPassword(ctx context.Context, userID int64, passwordHash string) error
+	// UpdateUserLastLogin stamps the user's most recent successful
+	// login with the current time and the caller's address.
+	UpdateUserLastLogin(ctx context.Context, userID int64, ip string) error
+	// DeleteUser permanently removes the user and everything they own:
+	// items, archived versions, refresh tokens and audit entries.
+	DeleteUser(ctx context.Context, userID int64) error
+
+	// Refresh tokens are opaque strings issued alongside JWTs; storing
+	// them server-side is what makes revocation possible.
+	CreateRefreshToken(ctx context.Context, userID int64, token string, expiresAt time.Time) error
+	GetRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error)
+	DeleteRefreshToken(ctx context.Context, token string) error
+	// DeleteUserRefreshTokens revokes every refresh token the user
+	// holds, ending all of their long-lived sessions at once.
+	DeleteUserRefreshTokens(ctx context.Context, userID int64) error
+
+	// RevokeToken blacklists an access token by its jti until the token
+	// would have expired anyway; IsTokenRevoked checks the list.
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	AddItem(ctx context.Context, item *models.Item) (int64, error)
+	// AddItems inserts all items in one atomic batch: either every item
+	// is stored or none are. The returned IDs parallel items.
+	AddItems(ctx context.Context, items []*models.Item) ([]int64, error)
+	GetItem(ctx context.Context, userID, itemID int64) (*models.Item, error)
+	GetItemByName(ctx context.Context, userID int64, name string) (*models.Item, error)
+	// ListItems returns one page of the user's items, plus the total
+	// matching item count. A limit of 0 or less means no limit. A
+	// non-empty filter keeps only items whose metadata contains every
+	// given key/value pair; a non-empty tags slice keeps only items
+	// carrying every listed tag. sortBy and order pick the ordering
+	// (see the SortBy and Order constants); zero values keep the
+	// stable default (created_at, id) ascending order.
+	ListItems(ctx context.Context, userID, limit, offset int64, filter map[string]string, tags []string, sortBy, order string) ([]*models.Item, int64, error)
+	// SearchItemsByName returns items whose name contains query,
+	// case-insensitively.
+	SearchItemsByName(ctx context.Context, userID int64, query string) ([]*models.Item, error)
+	// CountItemsByType returns per-type statistics for the user's live
+	// items, computed in a single aggregate query.
+	CountItemsByType(ctx context.Context, userID int64) (map[string]TypeStats, error)
+	UpdateItem(ctx context.Context, item *models.Item) error
+	// UpdateItemByName updates the item with item.Name atomically,
+	// checking item.Version (when non-zero) against the stored version
+	// in the same transaction.
+	UpdateItemByName(ctx context.Context, item *models.Item) error
+	// ListItemVersions returns archived prior values of an item, newest
+	// first. Every update archives the row it replaced.
+	ListItemVersions(ctx context.Context, userID, itemID int64) ([]*models.ItemVersion, error)
+	// DeleteItem soft-deletes an item: the row stays as a tombstone
+	// (hidden from reads, visible to ItemsSince) until purged.
+	DeleteItem(ctx context.Context, userID, itemID int64) error
+	// DeleteItems soft-deletes the named items in one transaction and
+	// returns the names that had no live item; the rest are deleted
+	// regardless.
+	DeleteItems(ctx context.Context, userID int64, names []string) (missing []string, err error)
+	// ListTrash returns the user's soft-deleted items, most recently
+	// deleted first, so a mistaken delete can be found and restored.
+	ListTrash(ctx context.Context, userID int64) ([]*models.Item, error)
+	// RestoreItem clears a soft-deleted item's tombstone and bumps its
+	// version, bringing it back into normal reads.
+	RestoreItem(ctx context.Context, userID, itemID int64) error
+	// PurgeDeleted hard-deletes items soft-deleted at or before
+	// olderThan and returns how many were removed. A userID of 0
+	// purges across all users.
+	PurgeDeleted(ctx context.Context, userID int64, olderThan time.Time) (int64, error)
+	// PurgeItem hard-deletes one item by ID, or by name when itemID
+	// is zero, whether or not it was soft-deleted first.
+	PurgeItem(ctx context.Context, userID, itemID int64, name string) error
+	// ItemsSince returns items changed after since, for sync.
+	// Soft-deleted items are included as tombstones.
+	ItemsSince(ctx context.Context, userID int64, since time.Time) ([]*models.Item, error)
+	// ItemsSincePage returns one keyset page of items changed after
+	// since, ordered by (updated_at, id) and starting strictly after
+	// the (afterUpdated, afterID) cursor. It reports whether more
+	// pages remain. Soft-deleted items are included as tombstones.
+	ItemsSincePage(ctx context.Context, userID int64, since, afterUpdated time.Time, afterID, limit int64) ([]*models.Item, bool, error)
+	// DeleteExpiredItems removes items whose expiry has passed and
+	// returns how many were removed.
+	DeleteExpiredItems(ctx context.Context, now time.Time) (int64, error)
+
+	// AppendAuditEntry records one data-access event in the append-only
+	// audit log, filling in the entry's ID and timestamp.
+	AppendAuditEntry(ctx context.Context, entry *models.AuditEntry) error
+	// ListAuditEntries returns the user's own audit entries, newest
+	// first. A limit of 0 or less means no limit.
+	ListAuditEntries(ctx context.Context, userID, limit int64) ([]*models.AuditEntry, error)
+
+	// Ping reports whether the backing store is reachable, for health
+	// checks.
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// PoolConfig tunes the database/sql connection pool. Zero values fall
+// back to the package defaults; negative values mean unlimited, with
+// the semantics of the corresponding database/sql setter.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// Default pool settings, sized for a small deployment. Operators tune
+// them through the server's -db-* flags.
+const (
+	DefaultMaxOpenConns    = 25
+	DefaultMaxIdleConns    = 5
+	DefaultConnMaxLifetime = 5 * time.Minute
+)
+
+// New picks a backend from the DSN scheme: postgres:// (or
+// postgresql://) selects Postgres, anything else is treated as a path
+// to a SQLite database file.
+func New(dsn string) (Storage, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		return NewPostgresStorage(dsn)
+	}
+	return NewSQLiteStorage(dsn)
+}
+
+// Sort fields and orders accepted by ListItems. The empty string keeps
+// the default (created, ascending).
+const (
+	SortByCreated = "created"
+	SortByUpdated = "updated"
+	SortByName    = "name"
+	SortByType    = "type"
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// sortColumns whitelists the ListItems sort fields and maps them to
+// their SQL columns; user input reaches ORDER BY no other way.
+var sortColumns = map[string]string{
+	"":            "created_at",
+	SortByCreated: "created_at",
+	SortByUpdated: "updated_at",
+	SortByName:    "name",
+	SortByType:    "type",
+}
+
+// ValidateSort rejects sort parameters outside the whitelist, so
+// callers can fail fast before touching storage.
+func ValidateSort(sortBy, order string) error {
+	if _, ok := sortColumns[sortBy]; !ok {
+		return fmt.Errorf("unknown sort field %q (want created, updated, name or type)", sortBy)
+	}
+	switch order {
+	case "", OrderAsc, OrderDesc:
+		return nil
+	}
+	return fmt.Errorf("unknown sort order %q (want asc or desc)", order)
+}
+
+// orderClause builds the ORDER BY clause for the given sort
+// parameters; id is the tiebreaker keeping pagination stable.
+func orderClause(sortBy, order string) (string, error) {
+	if err := ValidateSort(sortBy, order); err != nil {
+		return "", err
+	}
+	dir := ""
+	if order == OrderDesc {
+		dir = " DESC"
+	}
+	return "ORDER BY " + sortColumns[sortBy] + dir + ", id" + dir, nil
+}
+
+// TypeStats aggregates the live items of one type for a user, as
+// returned by CountItemsByType.
+type TypeStats struct {
+	Count int64
+	// TotalSize is the combined encrypted payload size in bytes.
+	TotalSize int64
+	// Oldest and Newest are the creation times of the type's oldest
+	// and newest items.
+	Oldest time.Time
+	Newest time.Time
+}
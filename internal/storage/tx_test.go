@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ar11/gophkeeper/internal/models"
+)
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "gophkeeper.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer s.Close()
+	user := mustCreateUser(t, s, "txuser")
+
+	failure := errors.New("simulated failure")
+	err = s.withTx(context.Background(), func(tx *sql.Tx) error {
+		if _, err := tx.Exec(
+			`INSERT INTO items (user_id, name, type, data, metadata, tags, created_at, updated_at)
+			 VALUES (?, 'doomed', 'text', x'00', '{}', '[]', datetime('now'), datetime('now'))`,
+			user.ID,
+		); err != nil {
+			t.Fatalf("insert inside tx: %v", err)
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("got %v, want the callback's error back", err)
+	}
+	if _, err := s.GetItemByName(context.Background(), user.ID, "doomed"); !errors.Is(err, ErrItemNotFound) {
+		t.Fatalf("insert survived the rollback: %v", err)
+	}
+
+	// The connection is usable again after the rollback.
+	if _, err := s.AddItem(context.Background(), &models.Item{
+		UserID: user.ID, Name: "survivor", Type: models.TypeText, Data: []byte("x"),
+	}); err != nil {
+		t.Fatalf("add item after rollback: %v", err)
+	}
+}
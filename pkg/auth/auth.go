@@ -0,0 +1,214 @@
+// Package auth provides account-password hashing and JWT issuing and
+// verification for the GophKeeper server.
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TokenDuration is the default validity window for access tokens.
+const TokenDuration = 24 * time.Hour
+
+// RefreshTokenDuration is how long refresh tokens stay valid. They are
+// rotated on every use, so an active session never hits this limit.
+const RefreshTokenDuration = 30 * 24 * time.Hour
+
+// ErrInvalidToken is returned when a token fails parsing or signature
+// verification.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrExpiredToken is returned for a well-formed, correctly signed token
+// whose validity window has passed, so clients can try a refresh.
+var ErrExpiredToken = errors.New("token expired")
+
+// Claims is the JWT payload carried by GophKeeper tokens.
+type Claims struct {
+	jwt.RegisteredClaims
+	UserID int64 `json:"uid"`
+}
+
+// TokenConfig bundles everything needed to issue and verify access
+// tokens: the validity duration, the signing method and the key
+// material. Construct one with NewHS256Config or NewRS256Config.
+type TokenConfig struct {
+	// Duration is how long issued tokens stay valid; zero means the
+	// TokenDuration default.
+	Duration time.Duration
+
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+// NewHS256Config returns a config for symmetric HS256 signing with a
+// shared secret. This is the default scheme.
+func NewHS256Config(secret []byte) *TokenConfig {
+	return &TokenConfig{
+		method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+// NewRS256Config returns a config for asymmetric RS256 signing. The
+// private key PEM is needed to issue tokens; services that only verify
+// may pass just the public key PEM.
+func NewRS256Config(privateKeyPEM, publicKeyPEM []byte) (*TokenConfig, error) {
+	cfg := &TokenConfig{method: jwt.SigningMethodRS256}
+	if len(privateKeyPEM) > 0 {
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse RSA private key: %w", err)
+		}
+		cfg.signKey = key
+		cfg.verifyKey = &key.PublicKey
+	}
+	if len(publicKeyPEM) > 0 {
+		pub, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("parse RSA public key: %w", err)
+		}
+		cfg.verifyKey = pub
+	}
+	if cfg.verifyKey == nil {
+		return nil, errors.New("RS256 config needs a private or public key")
+	}
+	return cfg, nil
+}
+
+// HashPassword hashes an account password with bcrypt at the default
+// cost.
+func HashPassword(password string) (string, error) {
+	return HashPasswordCost(password, bcrypt.DefaultCost)
+}
+
+// HashPasswordCost hashes an account password with bcrypt at the given
+// cost. A cost of zero or less falls back to bcrypt's default.
+func HashPasswordCost(password string, cost int) (string, error) {
+	if cost <= 0 {
+		cost = bcrypt.DefaultCost
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// NeedsRehash reports whether hash was generated at a cost below want,
+// i.e. it should be re-hashed the next time the password is verified.
+// Unparseable hashes report false; verification will reject them anyway.
+func NeedsRehash(hash string, want int) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost < want
+}
+
+// CheckPassword reports whether password matches the stored bcrypt
+// hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// GenerateToken issues a signed JWT for userID. Every token carries a
+// unique jti so it can be revoked individually.
+func (c *TokenConfig) GenerateToken(userID int64) (string, error) {
+	if c.signKey == nil {
+		return "", errors.New("token config has no signing key")
+	}
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+	duration := c.Duration
+	if duration == 0 {
+		duration = TokenDuration
+	}
+	now := time.Now()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+		},
+		UserID: userID,
+	}
+	token := jwt.NewWithClaims(c.method, claims)
+	signed, err := token.SignedString(c.signKey)
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
+	return signed, nil
+}
+
+// ParseClaims verifies a token against the config and returns its full
+// claims, for callers that need more than the user ID (e.g. the jti
+// for revocation). A token signed with a different method is rejected
+// regardless of key material.
+func (c *TokenConfig) ParseClaims(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
+		if t.Method.Alg() != c.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return c.verifyKey, nil
+	})
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, ErrExpiredToken
+	}
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// ParseToken verifies a token and returns the user ID it was issued
+// for.
+func (c *TokenConfig) ParseToken(tokenString string) (int64, error) {
+	claims, err := c.ParseClaims(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
+
+// GenerateToken issues an HS256 token with the default duration, for
+// callers that do not need a custom TokenConfig.
+func GenerateToken(secret []byte, userID int64) (string, error) {
+	return NewHS256Config(secret).GenerateToken(userID)
+}
+
+// ParseToken verifies an HS256 token and returns the user ID it was
+// issued for.
+func ParseToken(secret []byte, tokenString string) (int64, error) {
+	return NewHS256Config(secret).ParseToken(tokenString)
+}
+
+// ParseClaims verifies an HS256 token and returns its full claims.
+func ParseClaims(secret []byte, tokenString string) (*Claims, error) {
+	return NewHS256Config(secret).ParseClaims(tokenString)
+}
+
+// newTokenID returns a random jti for a fresh token.
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate token id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// NewRefreshToken returns a fresh opaque refresh token.
+func NewRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate refresh token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
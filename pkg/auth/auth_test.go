@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testRSAKeyPEM generates a throwaway RSA key pair in PEM form.
+func testRSAKeyPEM(t *testing.T) (privPEM, pubPEM []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPEM = pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	return privPEM, pubPEM
+}
+
+func TestHS256RoundTrip(t *testing.T) {
+	cfg := NewHS256Config([]byte("secret"))
+	token, err := cfg.GenerateToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID, err := cfg.ParseToken(token)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if userID != 7 {
+		t.Fatalf("got user %d, want 7", userID)
+	}
+}
+
+func TestRS256RoundTrip(t *testing.T) {
+	privPEM, pubPEM := testRSAKeyPEM(t)
+	signer, err := NewRS256Config(privPEM, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := signer.GenerateToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A verify-only config with just the public key must accept it.
+	verifier, err := NewRS256Config(nil, pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := verifier.ParseClaims(token)
+	if err != nil {
+		t.Fatalf("verify with public key: %v", err)
+	}
+	if claims.UserID != 7 {
+		t.Fatalf("got user %d, want 7", claims.UserID)
+	}
+	if claims.ID == "" {
+		t.Fatal("token has no jti")
+	}
+
+	// ...but must not be able to sign.
+	if _, err := verifier.GenerateToken(7); err == nil {
+		t.Fatal("verify-only config issued a token")
+	}
+}
+
+func TestRS256RejectsHS256Token(t *testing.T) {
+	privPEM, _ := testRSAKeyPEM(t)
+	rs, err := NewRS256Config(privPEM, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hsToken, err := NewHS256Config([]byte("secret")).GenerateToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.ParseClaims(hsToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("HS256 token under RS256 config: got %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestCustomDuration(t *testing.T) {
+	cfg := NewHS256Config([]byte("secret"))
+	cfg.Duration = time.Minute
+	token, err := cfg.GenerateToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := cfg.ParseClaims(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl > time.Minute || ttl < 30*time.Second {
+		t.Fatalf("token lives %v, want about a minute", ttl)
+	}
+}
+
+func TestExpiredToken(t *testing.T) {
+	cfg := NewHS256Config([]byte("secret"))
+	cfg.Duration = -time.Minute
+	token, err := cfg.GenerateToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cfg.ParseClaims(token); !errors.Is(err, ErrExpiredToken) {
+		t.Fatalf("got %v, want ErrExpiredToken", err)
+	}
+}
+
+func TestHashPasswordCostAndNeedsRehash(t *testing.T) {
+	hash, err := HashPasswordCost("secret-pw", bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !CheckPassword(hash, "secret-pw") {
+		t.Fatal("low-cost hash does not verify")
+	}
+	if !NeedsRehash(hash, bcrypt.MinCost+1) {
+		t.Fatal("hash below the wanted cost should need a rehash")
+	}
+	if NeedsRehash(hash, bcrypt.MinCost) {
+		t.Fatal("hash at the wanted cost should not need a rehash")
+	}
+	if NeedsRehash("not a bcrypt hash", bcrypt.DefaultCost) {
+		t.Fatal("garbage input should not report a rehash")
+	}
+
+	// Zero cost falls back to the default.
+	hash, err = HashPasswordCost("secret-pw", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost, _ := bcrypt.Cost([]byte(hash)); cost != bcrypt.DefaultCost {
+		t.Fatalf("cost = %d, want the default %d", cost, bcrypt.DefaultCost)
+	}
+}